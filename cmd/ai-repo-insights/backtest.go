@@ -0,0 +1,164 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/backtest"
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/fetcher"
+	"ai-repo-insights/internal/pipeline"
+)
+
+// baselineCandidates score by a single trending window, for comparison with the configured weights
+var baselineCandidates = []backtest.Candidate{
+	{Name: "today-only", Weights: config.ScoreWeights{Today: 1}},
+	{Name: "week-only", Weights: config.ScoreWeights{Week: 1}},
+	{Name: "month-only", Weights: config.ScoreWeights{Month: 1}},
+}
+
+// runBacktest replays saved trending snapshots through candidate score weights
+func runBacktest(args []string) int {
+	var candidates []backtest.Candidate
+
+	fs := flag.NewFlagSet("backtest", flag.ExitOnError)
+	configDir := fs.String("config", "config", "Path to configuration directory")
+	topN := fs.Int("top-n", 0, "Top list size for retention and dark-horse hits (default: settings top_n)")
+	horizon := fs.Int("horizon", 7, "Days ahead to measure outcomes")
+	threshold := fs.Int("dark-horse-threshold", 0, "Score that makes a repository outside the top N a dark horse (default: settings dark_horse_accel_threshold)")
+	format := fs.String("format", "table", "Output format: table or json")
+	fs.Func("weights", "Candidate weights as [name=]today,week,month; repeatable (default: the configured weights and single-window baselines)",
+		func(value string) error {
+			candidate, err := parseCandidate(value)
+			if err != nil {
+				return err
+			}
+			candidates = append(candidates, candidate)
+			return nil
+		})
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: ai-repo-insights backtest [options] [snapshot.json ...]")
+		fs.PrintDefaults()
+	}
+
+	paths, err := parseInterspersed(fs, args)
+	if err != nil {
+		return 2
+	}
+	if (*format != "table" && *format != "json") || *horizon < 1 {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, ok := loadValidConfig(*configDir)
+	if !ok {
+		return 1
+	}
+
+	if len(paths) == 0 {
+		paths, err = fetcher.ListRaw(fetcher.RawDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "No snapshots to replay: %s\n", err)
+			return 1
+		}
+	}
+	raw, err := fetcher.LoadRawSnapshots(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load snapshot: %s\n", err)
+		return 1
+	}
+	// Snapshots given on the command line may be in any order; replay them by date
+	sort.SliceStable(raw, func(i, j int) bool { return raw[i].Date.Before(raw[j].Date) })
+	if len(raw) < 2 {
+		fmt.Fprintln(os.Stderr, "Backtesting needs at least two snapshots")
+		return 1
+	}
+
+	repoClassifier := pipeline.NewRepoClassifier(*cfg, os.Getenv("LLM_API_KEY"), zerolog.Nop())
+	snapshots := make([]backtest.Snapshot, 0, len(raw))
+	for _, snapshot := range raw {
+		snapshots = append(snapshots, backtest.Snapshot{
+			Date:  snapshot.Date,
+			Repos: repoClassifier.Classify(snapshot.Repos),
+		})
+	}
+
+	configured := backtest.Candidate{Name: "config", Weights: cfg.Settings.ScoreWeights}
+	if len(candidates) == 0 {
+		candidates = append([]backtest.Candidate{configured}, baselineCandidates...)
+	} else {
+		candidates = append([]backtest.Candidate{configured}, candidates...)
+	}
+
+	opts := backtest.Options{
+		TopN:               cfg.Settings.TopN,
+		HorizonDays:        *horizon,
+		DarkHorseThreshold: cfg.Settings.DarkHorseAccelThreshold,
+	}
+	if *topN > 0 {
+		opts.TopN = *topN
+	}
+	if *threshold > 0 {
+		opts.DarkHorseThreshold = *threshold
+	}
+
+	results := backtest.Run(snapshots, candidates, opts)
+	if len(results) > 0 && results[0].Periods == 0 {
+		fmt.Fprintf(os.Stderr, "No snapshot pairs %d to %d days apart in %d snapshots\n", *horizon, 2**horizon, len(snapshots))
+		return 1
+	}
+
+	if *format == "json" {
+		return printJSON(results)
+	}
+
+	fmt.Printf("Snapshots: %d  Periods: %d  Horizon: %d days  Top N: %d  Dark-horse threshold: %d\n\n",
+		len(snapshots), results[0].Periods, *horizon, opts.TopN, opts.DarkHorseThreshold)
+	printBacktestResults(results)
+	return 0
+}
+
+// parseCandidate parses [name=]today,week,month
+func parseCandidate(value string) (backtest.Candidate, error) {
+	name, weights, named := strings.Cut(value, "=")
+	if !named {
+		name, weights = value, value
+	}
+
+	parts := strings.Split(weights, ",")
+	if len(parts) != 3 {
+		return backtest.Candidate{}, fmt.Errorf("expected three comma-separated weights, got %q", weights)
+	}
+	var values [3]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || v < 0 {
+			return backtest.Candidate{}, fmt.Errorf("invalid weight %q", part)
+		}
+		values[i] = v
+	}
+
+	return backtest.Candidate{
+		Name:    name,
+		Weights: config.ScoreWeights{Today: values[0], Week: values[1], Month: values[2]},
+	}, nil
+}
+
+// printBacktestResults prints the candidates as an aligned comparison table
+func printBacktestResults(results []backtest.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CANDIDATE\tTODAY\tWEEK\tMONTH\tRETENTION\tSPEARMAN\tDARK HORSES\tHIT RATE")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%g\t%g\t%g\t%.3f\t%.3f\t%d/%d\t%.3f\n",
+			r.Name, r.Weights.Today, r.Weights.Week, r.Weights.Month,
+			r.Retention, r.RankCorrelation, r.DarkHorseHits, r.DarkHorses, r.DarkHorseHitRate)
+	}
+	w.Flush()
+}
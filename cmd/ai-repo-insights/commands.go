@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"ai-repo-insights/internal/config"
+)
+
+// subcommands maps subcommand names to their entry points; each returns an exit code
+var subcommands = map[string]func(args []string) int{
+	"explain": runExplain,
+	"eval":    runEval,
+
+	"suggest-keywords": runSuggestKeywords,
+	"backtest":         runBacktest,
+	"migrate-history":  runMigrateHistory,
+	"import-reports":   runImportReports,
+}
+
+// loadValidConfig loads and validates configuration, printing problems to stderr
+func loadValidConfig(configDir string) (*config.Config, bool) {
+	cfg, err := config.Load(configDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %s\n", err)
+		return nil, false
+	}
+
+	if errors := cfg.Validate(); len(errors) > 0 {
+		for _, errMsg := range errors {
+			fmt.Fprintf(os.Stderr, "Configuration error: %s\n", errMsg)
+		}
+		return nil, false
+	}
+
+	return cfg, true
+}
+
+// parseInterspersed parses flags that may appear before or after positional arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/evaluation"
+	"ai-repo-insights/internal/pipeline"
+)
+
+// runEval measures the configured classifier against a labeled JSONL dataset
+func runEval(args []string) int {
+	fs := flag.NewFlagSet("eval", flag.ExitOnError)
+	configDir := fs.String("config", "config", "Path to configuration directory")
+	format := fs.String("format", "table", "Output format: table or json")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: ai-repo-insights eval [options] dataset.jsonl")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 || (*format != "table" && *format != "json") {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, ok := loadValidConfig(*configDir)
+	if !ok {
+		return 1
+	}
+
+	examples, err := evaluation.LoadDataset(positional[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load dataset: %s\n", err)
+		return 1
+	}
+
+	repoClassifier := pipeline.NewRepoClassifier(*cfg, os.Getenv("LLM_API_KEY"), zerolog.Nop())
+	report := evaluation.Evaluate(repoClassifier, cfg.Keywords.OrderedCategories(), examples)
+
+	if *format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode report: %s\n", err)
+			return 1
+		}
+		fmt.Println(string(data))
+		return 0
+	}
+
+	printEvalReport(report)
+	return 0
+}
+
+// printEvalReport prints an evaluation report as aligned tables
+func printEvalReport(report evaluation.Report) {
+	fmt.Printf("Examples: %d  Accuracy: %.3f\n\n", report.Total, report.Accuracy)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LABEL\tTP\tFP\tFN\tPRECISION\tRECALL\tF1")
+	printMetricsRow(w, "inclusion", report.Inclusion)
+	for _, category := range report.Categories {
+		printMetricsRow(w, category.Name, category.Metrics)
+	}
+	w.Flush()
+
+	fmt.Println("\nConfusion matrix (rows: expected, columns: predicted):")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "\t%s\t\n", strings.Join(report.Confusion.Labels, "\t"))
+	for i, label := range report.Confusion.Labels {
+		fmt.Fprintf(w, "%s", label)
+		for _, count := range report.Confusion.Counts[i] {
+			fmt.Fprintf(w, "\t%d", count)
+		}
+		fmt.Fprintln(w, "\t")
+	}
+	w.Flush()
+
+	fmt.Printf("\nMisclassified (%d):\n", len(report.Misclassified))
+	if len(report.Misclassified) == 0 {
+		fmt.Println("  (none)")
+		return
+	}
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, miss := range report.Misclassified {
+		fmt.Fprintf(w, "  %s\texpected %s\tgot %s\n", miss.Repo, miss.Expected, miss.Actual)
+	}
+	w.Flush()
+}
+
+// printMetricsRow prints one row of the metrics table
+func printMetricsRow(w *tabwriter.Writer, label string, m evaluation.Metrics) {
+	fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.3f\t%.3f\t%.3f\n",
+		label, m.TruePositives, m.FalsePositives, m.FalseNegatives, m.Precision, m.Recall, m.F1)
+}
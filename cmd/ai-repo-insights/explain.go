@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/classifier"
+	"ai-repo-insights/internal/fetcher"
+	"ai-repo-insights/internal/models"
+	"ai-repo-insights/internal/pipeline"
+)
+
+// runExplain prints the classification trace of one repository from a trending snapshot
+func runExplain(args []string) int {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	configDir := fs.String("config", "config", "Path to configuration directory")
+	snapshot := fs.String("snapshot", "", "Trending snapshot file (default: latest in "+fetcher.RawDir+")")
+	asJSON := fs.Bool("json", false, "Print the trace as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: ai-repo-insights explain [options] owner/repo")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 || !strings.Contains(positional[0], "/") {
+		fs.Usage()
+		return 2
+	}
+	key := positional[0]
+
+	cfg, ok := loadValidConfig(*configDir)
+	if !ok {
+		return 1
+	}
+
+	snapshotPath := *snapshot
+	if snapshotPath == "" {
+		snapshotPath, err = fetcher.LatestRaw(fetcher.RawDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "No snapshot to explain from: %s\n", err)
+			return 1
+		}
+	}
+
+	repos, err := fetcher.LoadRaw(snapshotPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load snapshot: %s\n", err)
+		return 1
+	}
+
+	repo, found := findRepo(repos, key)
+	if !found {
+		fmt.Fprintf(os.Stderr, "Repository %s not found in %s\n", key, snapshotPath)
+		return 1
+	}
+
+	var trace models.ClassificationTrace
+	switch repoClassifier := pipeline.NewRepoClassifier(*cfg, os.Getenv("LLM_API_KEY"), zerolog.Nop()).(type) {
+	case *classifier.EmbeddingClassifier:
+		trace, err = repoClassifier.Explain(repo)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to compute similarities: %s\n", err)
+			return 1
+		}
+	case *classifier.Classifier:
+		trace = repoClassifier.Explain(repo)
+	}
+
+	if *asJSON {
+		data, err := json.MarshalIndent(trace, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode trace: %s\n", err)
+			return 1
+		}
+		fmt.Println(string(data))
+		return 0
+	}
+
+	printTrace(repo, snapshotPath, trace)
+	return 0
+}
+
+// findRepo finds a repository by owner/name, ignoring case
+func findRepo(repos []models.RepoMetadata, key string) (models.RepoMetadata, bool) {
+	for _, repo := range repos {
+		if strings.EqualFold(repo.Key(), key) {
+			return repo, true
+		}
+	}
+	return models.RepoMetadata{}, false
+}
+
+// printTrace prints a classification trace in a readable form
+func printTrace(repo models.RepoMetadata, snapshotPath string, trace models.ClassificationTrace) {
+	decision := "EXCLUDED"
+	if trace.Included {
+		decision = "INCLUDED"
+	}
+
+	fmt.Printf("%s (snapshot: %s)\n", repo.Key(), snapshotPath)
+	fmt.Printf("  Decision:    %s - %s\n", decision, trace.Reason)
+	fmt.Printf("  Match score: %.2f", trace.MatchScore)
+	if trace.MinMatchScore > 0 {
+		fmt.Printf(" (minimum %.2f)", trace.MinMatchScore)
+	}
+	fmt.Println()
+
+	fmt.Println("\nMatched keywords:")
+	if len(trace.MatchedKeywords) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, field := range []string{"name", "description", "topics", "readme"} {
+		if keywords := trace.MatchedKeywords[field]; len(keywords) > 0 {
+			fmt.Printf("  %-12s %s\n", field+":", strings.Join(keywords, ", "))
+		}
+	}
+	if len(trace.MatchedRules) > 0 {
+		fmt.Printf("  %-12s %s\n", "rules:", strings.Join(trace.MatchedRules, "; "))
+	}
+	if trace.ExcludedBy != "" {
+		fmt.Printf("  %-12s %s\n", "excluded by:", trace.ExcludedBy)
+	}
+	if trace.ContentType != "" {
+		fmt.Printf("  %-12s %s", "content:", trace.ContentType)
+		if len(trace.ContentSignals) > 0 {
+			fmt.Printf(" [%s]", strings.Join(trace.ContentSignals, ", "))
+		}
+		fmt.Println()
+	}
+	if trace.Override != "" {
+		fmt.Printf("  %-12s %s\n", "override:", trace.Override)
+	}
+
+	fmt.Println("\nCategories:")
+	if len(trace.Categories) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, category := range trace.Categories {
+		marker := " "
+		if category.Name == trace.PrimaryCategory {
+			marker = "*"
+		}
+		matches := append(append([]string{}, category.Keywords...), category.Rules...)
+		fmt.Printf(" %s %-20s matches=%d score=%.2f  [%s]\n",
+			marker, category.Name, category.MatchCount, category.Score, strings.Join(matches, ", "))
+	}
+	if len(trace.Similarities) > 0 {
+		fmt.Println("\nPrototype similarities:")
+		names := make([]string, 0, len(trace.Similarities))
+		for name := range trace.Similarities {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("  %-20s %.3f\n", name, trace.Similarities[name])
+		}
+	}
+	if trace.PrimaryCategory != "" {
+		fmt.Printf("\nPrimary category: %s\n", trace.PrimaryCategory)
+	}
+	if trace.TieBreak != "" {
+		fmt.Printf("Tie-break: %s\n", trace.TieBreak)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/history"
+	"ai-repo-insights/internal/importer"
+	"ai-repo-insights/internal/models"
+	"ai-repo-insights/internal/pipeline"
+)
+
+// runImportReports backfills summary backups and history from generated Markdown reports
+func runImportReports(args []string) int {
+	fs := flag.NewFlagSet("import-reports", flag.ExitOnError)
+	historyPath := fs.String("history", "data/history.json", "Path to the history file to rebuild")
+	overwrite := fs.Bool("overwrite", false, "Replace existing summary backups with the parsed reports")
+	dryRun := fs.Bool("dry-run", false, "Report what would be imported without writing anything")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: ai-repo-insights import-reports [options] [report.md ...]")
+		fs.PrintDefaults()
+	}
+
+	paths, err := parseInterspersed(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(paths) == 0 {
+		paths, _ = filepath.Glob(filepath.Join("reports", "*.md"))
+		sort.Strings(paths)
+	}
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "No reports to import")
+		return 1
+	}
+
+	reports := make(map[string]importer.Report, len(paths))
+	sources := make(map[string]string, len(paths))
+	duplicates := false
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s: %s\n", path, err)
+			continue
+		}
+		report, err := importer.Parse(string(data))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s: %s\n", path, err)
+			continue
+		}
+		if source, exists := sources[report.ReportID]; exists {
+			fmt.Fprintf(os.Stderr, "Report ID %s is in both %s and %s\n", report.ReportID, source, path)
+			duplicates = true
+			continue
+		}
+		sources[report.ReportID] = path
+		reports[report.ReportID] = report
+	}
+	// Either copy could be the right one, so nothing is written until the ID is unique
+	if duplicates {
+		fmt.Fprintln(os.Stderr, "Duplicate report IDs, nothing imported")
+		return 1
+	}
+	if len(reports) == 0 {
+		fmt.Fprintln(os.Stderr, "No reports could be parsed")
+		return 1
+	}
+
+	parsed := len(reports)
+	written := 0
+	for reportID, report := range reports {
+		path := filepath.Join(pipeline.SummariesDir, reportID+".json")
+		if _, err := os.Stat(path); err == nil && !*overwrite {
+			continue
+		}
+		written++
+		if *dryRun {
+			continue
+		}
+		if err := writeSummaryBackup(path, report.Summary); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write %s: %s\n", path, err)
+			return 1
+		}
+	}
+
+	// Summary backups carry repository IDs where known; parsed reports add published streaks
+	stored, err := pipeline.LoadSummaryRankings(pipeline.SummariesDir, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load summary backups: %s\n", err)
+		return 1
+	}
+	rankings := make([]history.Ranking, 0, len(stored)+len(reports))
+	for _, ranking := range stored {
+		if report, exists := reports[ranking.ReportID]; exists {
+			ranking.Streaks = report.Ranking().Streaks
+			delete(reports, ranking.ReportID)
+		}
+		rankings = append(rankings, ranking)
+	}
+	for _, report := range reports {
+		rankings = append(rankings, report.Ranking())
+	}
+
+	manager := history.NewManager(*historyPath, zerolog.Nop())
+	current, err := manager.LoadHistory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load history: %s\n", err)
+		return 1
+	}
+	history.SortRankings(rankings, current.Reports)
+	rebuilt := manager.Rebuild(rankings)
+
+	fmt.Printf("Parsed: %d of %d  Summary backups written: %d  Reports replayed: %d\n", parsed, len(paths), written, len(rankings))
+	fmt.Printf("History: %d tracked repositories, latest report %s\n", len(rebuilt.History), rebuilt.LatestReport)
+
+	if *dryRun {
+		return 0
+	}
+	if err := manager.SaveHistory(rebuilt); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to save history: %s\n", err)
+		return 1
+	}
+	fmt.Printf("Saved %s\n", *historyPath)
+	return 0
+}
+
+// writeSummaryBackup writes a summary backup in the format the pipeline saves
+func writeSummaryBackup(path string, summary models.SummaryJSON) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := models.MarshalJSON(summary)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
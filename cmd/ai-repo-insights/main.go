@@ -16,11 +16,19 @@ const (
 )
 
 func main() {
+	// Dispatch subcommands (e.g. "explain owner/repo") before parsing pipeline flags
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
 	// Define CLI flags
 	configDir := flag.String("config", "config", "Path to configuration directory")
 	reportID := flag.String("report-id", "", "Custom report ID (default: auto-generated)")
 	weekly := flag.Bool("weekly", false, "Use week-based report ID format")
 	logLevel := flag.String("log-level", "info", "Log level (debug, info, warn, error)")
+	force := flag.Bool("force", false, "Recompute history from the stored top list of every report")
 	showVersion := flag.Bool("version", false, "Show version information")
 	showHelp := flag.Bool("help", false, "Show help information")
 
@@ -63,7 +71,7 @@ func main() {
 
 	// Check required environment variables
 	if os.Getenv("GITHUB_TOKEN") == "" {
-		logger.Warn().Msg("GITHUB_TOKEN not set - star history fetching will fail")
+		logger.Warn().Msg("GITHUB_TOKEN not set - metadata enrichment will be skipped")
 	}
 	if os.Getenv("LLM_API_KEY") == "" {
 		logger.Warn().Msg("LLM_API_KEY not set - will use template-based report")
@@ -82,7 +90,7 @@ func main() {
 	logger.Info().Str("report_id", finalReportID).Msg("using report ID")
 
 	// Create and run pipeline
-	orchestrator := pipeline.NewOrchestrator(*cfg, logger)
+	orchestrator := pipeline.NewOrchestrator(*cfg, logger).WithForceHistory(*force)
 	result, err := orchestrator.RunPipeline(finalReportID)
 
 	if err != nil {
@@ -112,6 +120,20 @@ func main() {
 func printHelp() {
 	fmt.Printf("GitHub Insights v%s\n\n", version)
 	fmt.Println("Usage: github-insights [options]")
+	fmt.Println("       github-insights <command> [options] [args]")
+	fmt.Println("\nCommands:")
+	fmt.Println("  explain owner/repo")
+	fmt.Println("        Show why a repository was included, excluded or categorized")
+	fmt.Println("  eval dataset.jsonl")
+	fmt.Println("        Measure classifier precision and recall against labeled repositories")
+	fmt.Println("  suggest-keywords [snapshot.json ...]")
+	fmt.Println("        Propose keywords.json additions mined from saved trending snapshots")
+	fmt.Println("  backtest [snapshot.json ...]")
+	fmt.Println("        Compare score weights by how well they predict later trending")
+	fmt.Println("  migrate-history")
+	fmt.Println("        Re-key history by repository ID, merging renamed or transferred repositories")
+	fmt.Println("  import-reports [report.md ...]")
+	fmt.Println("        Backfill summary backups and history from generated Markdown reports")
 	fmt.Println("\nOptions:")
 	fmt.Println("  -config string")
 	fmt.Println("        Path to configuration directory (default: config)")
@@ -121,19 +143,26 @@ func printHelp() {
 	fmt.Println("        Use week-based report ID format")
 	fmt.Println("  -log-level string")
 	fmt.Println("        Log level: debug, info, warn, error (default: info)")
+	fmt.Println("  -force")
+	fmt.Println("        Recompute history from the stored top list of every report (required to re-run an earlier report)")
 	fmt.Println("  -version")
 	fmt.Println("        Show version information")
 	fmt.Println("  -help")
 	fmt.Println("        Show this help message")
 	fmt.Println("\nEnvironment Variables:")
-	fmt.Println("  GITHUB_TOKEN    GitHub API token (required)")
+	fmt.Println("  GITHUB_TOKEN    GitHub API token (recommended, enables metadata enrichment)")
 	fmt.Println("  LLM_API_KEY     LLM API key (optional, uses template if not set)")
 	fmt.Println("\nExamples:")
 	fmt.Println("  github-insights")
 	fmt.Println("  github-insights -weekly")
 	fmt.Println("  github-insights -config ./custom-config")
 	fmt.Println("  github-insights -report-id 2024-02-week6")
+	fmt.Println("  github-insights -report-id 2024-02-week6 -force")
 	fmt.Println("  github-insights -log-level debug")
+	fmt.Println("  github-insights explain langchain-ai/langgraph")
+	fmt.Println("  github-insights eval examples/eval/labeled.jsonl -format json")
+	fmt.Println("  github-insights backtest -weights recent=0.8,0.2,0")
+	fmt.Println("  github-insights import-reports -dry-run")
 }
 
 // generateDailyReportID generates a daily report ID (YYYY-MM-DD)
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/enricher"
+	"ai-repo-insights/internal/history"
+	"ai-repo-insights/internal/models"
+)
+
+// runMigrateHistory re-keys history entries by stable repository ID
+func runMigrateHistory(args []string) int {
+	fs := flag.NewFlagSet("migrate-history", flag.ExitOnError)
+	historyPath := fs.String("history", "data/history.json", "Path to the history file")
+	dryRun := fs.Bool("dry-run", false, "Report what would change without saving")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: ai-repo-insights migrate-history [options]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	if githubToken == "" {
+		fmt.Fprintln(os.Stderr, "GITHUB_TOKEN is required to resolve repository IDs")
+		return 1
+	}
+
+	manager := history.NewManager(*historyPath, zerolog.Nop())
+	hist, err := manager.LoadHistory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load history: %s\n", err)
+		return 1
+	}
+
+	legacy := history.LegacyKeys(hist)
+	if len(legacy) == 0 {
+		fmt.Println("All history entries are already keyed by repository ID")
+		return 0
+	}
+
+	repos := make([]models.RepoMetadata, 0, len(legacy))
+	for _, key := range legacy {
+		owner, name, _ := strings.Cut(key, "/")
+		repos = append(repos, models.RepoMetadata{Owner: owner, Name: name})
+	}
+
+	// GraphQL follows renames and transfers, so a former key resolves to the current repository's ID
+	resolved, err := enricher.New(githubToken, zerolog.Nop()).Enrich(repos)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to resolve repository IDs: %s\n", err)
+		return 1
+	}
+	ids := make(map[string]string, len(resolved))
+	var unresolved []string
+	for i, repo := range resolved {
+		if repo.ID == "" {
+			unresolved = append(unresolved, legacy[i])
+			continue
+		}
+		ids[legacy[i]] = repo.ID
+	}
+
+	merged := history.Migrate(hist, ids)
+
+	fmt.Printf("Legacy entries: %d  Resolved: %d  Merged: %d\n", len(legacy), len(ids), merged)
+	if len(unresolved) > 0 {
+		fmt.Printf("Unresolved (left keyed by owner/repo): %s\n", strings.Join(unresolved, ", "))
+	}
+
+	if *dryRun {
+		return 0
+	}
+	if err := manager.SaveHistory(hist); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to save history: %s\n", err)
+		return 1
+	}
+	fmt.Printf("Saved %s\n", *historyPath)
+	return 0
+}
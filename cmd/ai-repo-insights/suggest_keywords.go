@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"ai-repo-insights/internal/classifier"
+	"ai-repo-insights/internal/evaluation"
+	"ai-repo-insights/internal/fetcher"
+	"ai-repo-insights/internal/models"
+	"ai-repo-insights/internal/suggest"
+)
+
+// runSuggestKeywords mines saved trending snapshots for keyword candidates
+func runSuggestKeywords(args []string) int {
+	defaults := suggest.DefaultOptions()
+
+	fs := flag.NewFlagSet("suggest-keywords", flag.ExitOnError)
+	configDir := fs.String("config", "config", "Path to configuration directory")
+	snapshots := fs.Int("snapshots", 4, "Number of most recent snapshots in "+fetcher.RawDir+" to mine (0 for all)")
+	minSupport := fs.Int("min-support", defaults.MinSupport, "Minimum number of repositories containing a term")
+	minLift := fs.Float64("min-lift", defaults.MinLift, "Minimum frequency ratio against the reference group")
+	labels := fs.String("labels", "", "Labeled JSONL dataset (as for eval) whose misclassified repositories guide suggestions")
+	limit := fs.Int("limit", defaults.Limit, "Maximum suggestions per target (0 for no limit)")
+	format := fs.String("format", "patch", "Output format: patch (keywords.json additions), table or json")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: ai-repo-insights suggest-keywords [options] [snapshot.json ...]")
+		fs.PrintDefaults()
+	}
+
+	paths, err := parseInterspersed(fs, args)
+	if err != nil {
+		return 2
+	}
+	if *format != "patch" && *format != "table" && *format != "json" {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, ok := loadValidConfig(*configDir)
+	if !ok {
+		return 1
+	}
+
+	if len(paths) == 0 {
+		paths, err = fetcher.ListRaw(fetcher.RawDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "No snapshots to mine: %s\n", err)
+			return 1
+		}
+		if *snapshots > 0 && len(paths) > *snapshots {
+			paths = paths[len(paths)-*snapshots:]
+		}
+	}
+	if len(paths) == 0 {
+		fmt.Fprintf(os.Stderr, "No snapshots to mine in %s\n", fetcher.RawDir)
+		return 1
+	}
+
+	repos, err := loadSnapshots(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load snapshot: %s\n", err)
+		return 1
+	}
+
+	var labeled []evaluation.LabeledRepo
+	if *labels != "" {
+		labeled, err = evaluation.LoadDataset(*labels)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load dataset: %s\n", err)
+			return 1
+		}
+	}
+
+	repoClassifier := classifier.New(cfg.Keywords).WithOverrides(cfg.Overrides)
+	result := suggest.Suggest(repoClassifier, repos, labeled, suggest.Options{
+		MinSupport: *minSupport,
+		MinLift:    *minLift,
+		Limit:      *limit,
+	})
+
+	fmt.Fprintf(os.Stderr, "Mined %d repositories from %d snapshots: %d categorized, %d uncategorized, %d rejected, %d excluded\n",
+		len(repos), len(paths), result.Counts.Categorized, result.Counts.Uncategorized,
+		result.Counts.Rejected, result.Counts.Excluded)
+	if *labels != "" {
+		fmt.Fprintf(os.Stderr, "Labeled %d repositories: %d false positives, %d false negatives\n",
+			len(labeled), result.Counts.FalsePositives, result.Counts.FalseNegatives)
+	} else {
+		fmt.Fprintln(os.Stderr, "No -labels dataset given: exclude suggestions need labeled false positives")
+	}
+
+	switch *format {
+	case "table":
+		printSuggestions(result.Suggestions)
+		return 0
+	case "json":
+		return printJSON(result)
+	default:
+		return printJSON(result.Patch)
+	}
+}
+
+// loadSnapshots loads snapshots in order, keeping the latest record of each repository
+func loadSnapshots(paths []string) ([]models.RepoMetadata, error) {
+	index := make(map[string]int)
+	var repos []models.RepoMetadata
+
+	for _, path := range paths {
+		snapshot, err := fetcher.LoadRaw(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range snapshot {
+			key := strings.ToLower(repo.Key())
+			if i, exists := index[key]; exists {
+				repos[i] = repo
+				continue
+			}
+			index[key] = len(repos)
+			repos = append(repos, repo)
+		}
+	}
+
+	return repos, nil
+}
+
+// printJSON prints a value as indented JSON
+func printJSON(v interface{}) int {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to encode output: %s\n", err)
+		return 1
+	}
+	fmt.Println(string(data))
+	return 0
+}
+
+// printSuggestions prints suggestions as an aligned table
+func printSuggestions(suggestions []suggest.Suggestion) {
+	if len(suggestions) == 0 {
+		fmt.Println("No suggestions")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TARGET\tTERM\tSUPPORT\tLIFT\tSCORE\tEXAMPLES")
+	for _, s := range suggestions {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.2f\t%.2f\t%s\n",
+			s.Target, s.Term, s.Support, s.Lift, s.Score, strings.Join(s.Examples, ", "))
+	}
+	w.Flush()
+}
@@ -0,0 +1,190 @@
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+// Checks
+const (
+	// CheckHourlyBurst flags a single hour of stars far above the trending pace
+	CheckHourlyBurst = "hourly_burst"
+	// CheckNewAccounts flags a high share of stargazers with new or empty accounts
+	CheckNewAccounts = "new_accounts"
+	// CheckForkRatio flags stars far out of proportion to forks
+	CheckForkRatio = "fork_ratio"
+	// CheckWatcherRatio flags stars far out of proportion to watchers
+	CheckWatcherRatio = "watcher_ratio"
+)
+
+const (
+	// minSample is the fewest sampled stargazers the sample checks run on
+	minSample = 20
+	// minBurstStars is the fewest stars in one hour that can count as a burst
+	minBurstStars = 20
+	// minHourlyPace floors the expected stars per hour of a repository with no trending rate
+	minHourlyPace = 1.0
+	// emptyAccountWeight is how much an established account with no followers and no
+	// repositories counts toward the new-account share; many real users never publish
+	// anything, so an empty account is weaker evidence than a new one
+	emptyAccountWeight = 0.5
+)
+
+// Screener runs the star-manipulation checks
+type Screener struct {
+	settings config.AnomalyScreening
+}
+
+// NewScreener creates a screener with the given thresholds
+func NewScreener(settings config.AnomalyScreening) *Screener {
+	return &Screener{settings: settings}
+}
+
+// Screen returns the checks a repository fails. The burst and account checks need a
+// stargazer sample of at least minSample; the ratio checks use the metadata alone.
+func (s *Screener) Screen(repo models.RepoMetadata, sample []models.Stargazer) []models.AnomalyFlag {
+	var flags []models.AnomalyFlag
+
+	if len(sample) >= minSample {
+		if flag, failed := s.checkBurst(repo, sample); failed {
+			flags = append(flags, flag)
+		}
+		if flag, failed := s.checkAccounts(sample); failed {
+			flags = append(flags, flag)
+		}
+	}
+
+	if repo.Stars >= s.settings.RatioMinStars {
+		if ratio := float64(repo.Stars) / math.Max(float64(repo.Forks), 1); ratio >= s.settings.MaxStarsPerFork {
+			flags = append(flags, models.AnomalyFlag{
+				Check:     CheckForkRatio,
+				Value:     ratio,
+				Threshold: s.settings.MaxStarsPerFork,
+				Evidence:  fmt.Sprintf("%d stars against %d forks", repo.Stars, repo.Forks),
+			})
+		}
+		// Watchers are only known for enriched repositories
+		if repo.Enriched {
+			if ratio := float64(repo.Stars) / math.Max(float64(repo.Watchers), 1); ratio >= s.settings.MaxStarsPerWatcher {
+				flags = append(flags, models.AnomalyFlag{
+					Check:     CheckWatcherRatio,
+					Value:     ratio,
+					Threshold: s.settings.MaxStarsPerWatcher,
+					Evidence:  fmt.Sprintf("%d stars against %d watchers", repo.Stars, repo.Watchers),
+				})
+			}
+		}
+	}
+
+	return flags
+}
+
+// ScreenAll records the failed checks on each repository and returns a report for each
+// flagged one. samples holds stargazer samples keyed by repository key.
+func (s *Screener) ScreenAll(repos []models.ClassifiedRepo, samples map[string][]models.Stargazer) []models.AnomalyReport {
+	var reports []models.AnomalyReport
+
+	for i := range repos {
+		metadata := repos[i].Metadata
+		repos[i].Anomalies = s.Screen(metadata, samples[metadata.Key()])
+		if len(repos[i].Anomalies) == 0 {
+			continue
+		}
+		reports = append(reports, models.AnomalyReport{
+			RepoKey:  metadata.Key(),
+			URL:      metadata.URL,
+			Excluded: s.settings.Action == config.AnomalyActionExclude,
+			Flags:    repos[i].Anomalies,
+		})
+	}
+
+	return reports
+}
+
+// Exclude returns the repositories that failed no check
+func Exclude(repos []models.ClassifiedRepo) []models.ClassifiedRepo {
+	kept := make([]models.ClassifiedRepo, 0, len(repos))
+	for _, repo := range repos {
+		if len(repo.Anomalies) == 0 {
+			kept = append(kept, repo)
+		}
+	}
+	return kept
+}
+
+// checkBurst compares the busiest hour of the sample with the hourly pace implied by
+// the trending windows. The sample holds the latest stars, so for a fast-growing
+// repository it may span a single hour without that being a burst.
+func (s *Screener) checkBurst(repo models.RepoMetadata, sample []models.Stargazer) (models.AnomalyFlag, bool) {
+	times := make([]time.Time, 0, len(sample))
+	for _, stargazer := range sample {
+		times = append(times, stargazer.StarredAt)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	peak := 0
+	start := 0
+	for end := range times {
+		for times[end].Sub(times[start]) >= time.Hour {
+			start++
+		}
+		if count := end - start + 1; count > peak {
+			peak = count
+		}
+	}
+	if peak < minBurstStars {
+		return models.AnomalyFlag{}, false
+	}
+
+	dailyPace := math.Max(float64(repo.StarsToday),
+		math.Max(float64(repo.StarsThisWeek)/7, float64(repo.StarsThisMonth)/30))
+	hourlyPace := math.Max(dailyPace/24, minHourlyPace)
+
+	ratio := float64(peak) / hourlyPace
+	if ratio < s.settings.MaxBurstRatio {
+		return models.AnomalyFlag{}, false
+	}
+
+	return models.AnomalyFlag{
+		Check:     CheckHourlyBurst,
+		Value:     ratio,
+		Threshold: s.settings.MaxBurstRatio,
+		Evidence: fmt.Sprintf("%d of %d sampled stars within one hour, %.1f× the trending pace of %.0f stars/day",
+			peak, len(sample), ratio, dailyPace),
+	}, true
+}
+
+// checkAccounts measures the weighted share of stargazers whose account was under
+// NewAccountDays old when they starred; older accounts with no followers and no
+// repositories count emptyAccountWeight each
+func (s *Screener) checkAccounts(sample []models.Stargazer) (models.AnomalyFlag, bool) {
+	maxAge := time.Duration(s.settings.NewAccountDays) * 24 * time.Hour
+
+	newAccounts, emptyAccounts := 0, 0
+	for _, stargazer := range sample {
+		switch {
+		case stargazer.StarredAt.Sub(stargazer.AccountCreatedAt) < maxAge:
+			newAccounts++
+		case stargazer.Followers == 0 && stargazer.Repositories == 0:
+			emptyAccounts++
+		}
+	}
+
+	share := (float64(newAccounts) + emptyAccountWeight*float64(emptyAccounts)) / float64(len(sample))
+	if share < s.settings.MaxNewAccountShare {
+		return models.AnomalyFlag{}, false
+	}
+
+	return models.AnomalyFlag{
+		Check:     CheckNewAccounts,
+		Value:     share,
+		Threshold: s.settings.MaxNewAccountShare,
+		Evidence: fmt.Sprintf("%d of %d sampled stargazers have accounts under %d days old and %d more have no followers and repositories",
+			newAccounts, len(sample), s.settings.NewAccountDays, emptyAccounts),
+	}, true
+}
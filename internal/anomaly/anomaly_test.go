@@ -0,0 +1,151 @@
+package anomaly
+
+import (
+	"testing"
+	"time"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+var testSettings = config.AnomalyScreening{
+	Action:             config.AnomalyActionExclude,
+	MaxBurstRatio:      10,
+	MaxNewAccountShare: 0.5,
+	NewAccountDays:     30,
+	MaxStarsPerFork:    75,
+	MaxStarsPerWatcher: 500,
+	RatioMinStars:      1000,
+}
+
+// sampleOf builds n stargazers starred every spacing from start, with accounts of the given age
+func sampleOf(n int, start time.Time, spacing time.Duration, accountAge time.Duration, followers int) []models.Stargazer {
+	sample := make([]models.Stargazer, n)
+	for i := range sample {
+		starredAt := start.Add(time.Duration(i) * spacing)
+		sample[i] = models.Stargazer{
+			StarredAt:        starredAt,
+			AccountCreatedAt: starredAt.Add(-accountAge),
+			Followers:        followers,
+			Repositories:     followers,
+		}
+	}
+	return sample
+}
+
+func TestScreen(t *testing.T) {
+	start := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	year := 365 * 24 * time.Hour
+	organic := sampleOf(40, start, 30*time.Minute, 3*year, 5)
+
+	// A typical organic sample: many established users never publish anything and
+	// a few accounts are new
+	typical := sampleOf(40, start, 30*time.Minute, 3*year, 5)
+	for i := range typical {
+		switch {
+		case i%5 < 2:
+			typical[i].Followers, typical[i].Repositories = 0, 0
+		case i%10 == 2:
+			typical[i].AccountCreatedAt = typical[i].StarredAt.Add(-10 * 24 * time.Hour)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		repo     models.RepoMetadata
+		sample   []models.Stargazer
+		expected []string
+	}{
+		{
+			name:   "organic repository",
+			repo:   models.RepoMetadata{Stars: 5000, Forks: 400, Watchers: 60, Enriched: true, StarsToday: 48},
+			sample: organic,
+		},
+		{
+			name:   "typical sample with empty and new accounts",
+			repo:   models.RepoMetadata{Stars: 500, Forks: 40, StarsToday: 48},
+			sample: typical,
+		},
+		{
+			name:     "burst far above the daily pace",
+			repo:     models.RepoMetadata{Stars: 500, Forks: 40, StarsToday: 48},
+			sample:   sampleOf(40, start, time.Minute, 3*year, 5),
+			expected: []string{CheckHourlyBurst},
+		},
+		{
+			name:   "fast repository fills the sample within an hour",
+			repo:   models.RepoMetadata{Stars: 500, Forks: 40, StarsToday: 2400},
+			sample: sampleOf(40, start, time.Minute, 3*year, 5),
+		},
+		{
+			name:     "new accounts",
+			repo:     models.RepoMetadata{Stars: 500, Forks: 40, StarsToday: 48},
+			sample:   sampleOf(40, start, 30*time.Minute, 5*24*time.Hour, 5),
+			expected: []string{CheckNewAccounts},
+		},
+		{
+			name:     "only empty accounts",
+			repo:     models.RepoMetadata{Stars: 500, Forks: 40, StarsToday: 48},
+			sample:   sampleOf(40, start, 30*time.Minute, 3*year, 0),
+			expected: []string{CheckNewAccounts},
+		},
+		{
+			name:   "sample too small",
+			repo:   models.RepoMetadata{Stars: 500, Forks: 40},
+			sample: sampleOf(10, start, time.Second, 0, 0),
+		},
+		{
+			name:     "stars out of proportion to forks and watchers",
+			repo:     models.RepoMetadata{Stars: 20000, Forks: 100, Watchers: 10, Enriched: true},
+			expected: []string{CheckForkRatio, CheckWatcherRatio},
+		},
+		{
+			name:     "watchers unknown without enrichment",
+			repo:     models.RepoMetadata{Stars: 20000, Forks: 100},
+			expected: []string{CheckForkRatio},
+		},
+		{
+			name: "ratios ignored below the star minimum",
+			repo: models.RepoMetadata{Stars: 900, Forks: 0, Enriched: true},
+		},
+	}
+
+	screener := NewScreener(testSettings)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := screener.Screen(tt.repo, tt.sample)
+			if len(flags) != len(tt.expected) {
+				t.Fatalf("expected checks %v, got %+v", tt.expected, flags)
+			}
+			for i, flag := range flags {
+				if flag.Check != tt.expected[i] {
+					t.Errorf("flag %d: expected %s, got %s", i, tt.expected[i], flag.Check)
+				}
+				if flag.Evidence == "" || flag.Value < flag.Threshold {
+					t.Errorf("flag %d: expected evidence and a value over the threshold, got %+v", i, flag)
+				}
+			}
+		})
+	}
+}
+
+func TestScreenAllAndExclude(t *testing.T) {
+	repos := []models.ClassifiedRepo{
+		{Metadata: models.RepoMetadata{Owner: "o", Name: "clean", Stars: 2000, Forks: 200}},
+		{Metadata: models.RepoMetadata{Owner: "o", Name: "pumped", Stars: 20000, Forks: 10, URL: "https://github.com/o/pumped"}},
+	}
+
+	reports := NewScreener(testSettings).ScreenAll(repos, nil)
+
+	if len(reports) != 1 || reports[0].RepoKey != "o/pumped" || !reports[0].Excluded {
+		t.Fatalf("expected one excluded report for o/pumped, got %+v", reports)
+	}
+	if len(repos[1].Anomalies) != 1 || len(repos[0].Anomalies) != 0 {
+		t.Errorf("expected anomalies recorded on the flagged repository only, got %+v / %+v", repos[0].Anomalies, repos[1].Anomalies)
+	}
+
+	kept := Exclude(repos)
+	if len(kept) != 1 || kept[0].Metadata.Name != "clean" {
+		t.Errorf("expected only the clean repository to be kept, got %+v", kept)
+	}
+}
@@ -0,0 +1,239 @@
+package backtest
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"ai-repo-insights/internal/calculator"
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+// Candidate is a named set of score weights to evaluate
+type Candidate struct {
+	Name    string              `json:"name"`
+	Weights config.ScoreWeights `json:"weights"`
+}
+
+// Options controls how snapshots are paired and what counts as a hit
+type Options struct {
+	// TopN is the size of the top list used for retention and dark-horse hits
+	TopN int
+	// HorizonDays is how far ahead outcomes are measured; a snapshot is paired with
+	// the first later snapshot between HorizonDays and twice HorizonDays away
+	HorizonDays int
+	// DarkHorseThreshold is the score a repository outside the top N needs to be a dark horse
+	DarkHorseThreshold int
+}
+
+// Snapshot is the classified repositories of one saved trending snapshot
+type Snapshot struct {
+	Date  time.Time
+	Repos []models.ClassifiedRepo
+}
+
+// Result holds the predictive metrics of one candidate, averaged over all periods
+type Result struct {
+	Candidate
+	Periods int `json:"periods"`
+	// Retention is the share of the top N by score that is in the top N by Heat_30 at the horizon
+	Retention float64 `json:"retention"`
+	// RankCorrelation is the Spearman correlation between score and Heat_30 at the horizon
+	RankCorrelation float64 `json:"rank_correlation"`
+	DarkHorses      int     `json:"dark_horses"`
+	DarkHorseHits   int     `json:"dark_horse_hits"`
+	// DarkHorseHitRate is the share of dark horses that reach the top N by Heat_30 at the horizon
+	DarkHorseHitRate float64 `json:"dark_horse_hit_rate"`
+}
+
+// period pairs a snapshot with the snapshot its outcomes are measured in
+type period struct {
+	current Snapshot
+	// futureHeat30 maps lowercase repository keys to Heat_30 at the horizon
+	futureHeat30 map[string]int
+	// futureTop holds the lowercase keys of the top N by Heat_30 at the horizon
+	futureTop map[string]bool
+}
+
+// Run replays snapshots through each candidate's weights. For every snapshot with a
+// later snapshot at the horizon, repositories are scored with the candidate and compared
+// with what happened next:
+//   - retention: how much of the top N by score is in the top N by Heat_30 at the horizon
+//   - rank correlation: Spearman correlation of score with Heat_30 at the horizon, where
+//     repositories that left trending count as 0
+//   - dark-horse hit rate: how many repositories outside the current top N by Heat_30 with
+//     a score of at least DarkHorseThreshold reach the top N at the horizon
+//
+// Snapshots must be ordered oldest first.
+func Run(snapshots []Snapshot, candidates []Candidate, opts Options) []Result {
+	periods := pairPeriods(snapshots, opts)
+
+	results := make([]Result, 0, len(candidates))
+	for _, candidate := range candidates {
+		results = append(results, evaluate(candidate, periods, opts))
+	}
+	return results
+}
+
+// pairPeriods pairs each snapshot with the first snapshot at the horizon
+func pairPeriods(snapshots []Snapshot, opts Options) []period {
+	// Weights do not affect Heat_30, so outcomes are computed once
+	outcomes := calculator.New(0, 0)
+
+	var periods []period
+	for i, current := range snapshots {
+		for _, future := range snapshots[i+1:] {
+			days := int(future.Date.Sub(current.Date).Hours() / 24)
+			if days < opts.HorizonDays {
+				continue
+			}
+			if days > 2*opts.HorizonDays {
+				break
+			}
+
+			p := period{
+				current:      current,
+				futureHeat30: make(map[string]int),
+				futureTop:    make(map[string]bool),
+			}
+			ranked := outcomes.RankRepositories(outcomes.CalculateScores(future.Repos))
+			for rank, repo := range ranked {
+				key := strings.ToLower(repo.Key())
+				p.futureHeat30[key] = repo.Heat30
+				if rank < opts.TopN {
+					p.futureTop[key] = true
+				}
+			}
+			periods = append(periods, p)
+			break
+		}
+	}
+
+	return periods
+}
+
+// evaluate measures one candidate over all periods
+func evaluate(candidate Candidate, periods []period, opts Options) Result {
+	result := Result{Candidate: candidate, Periods: len(periods)}
+	calc := calculator.New(0, 0).WithWeights(candidate.Weights)
+
+	var retentionSum, correlationSum float64
+	var retentionPeriods, correlationPeriods int
+
+	for _, p := range periods {
+		scored := calc.CalculateScores(p.current.Repos)
+		if len(scored) == 0 {
+			continue
+		}
+
+		byScore := append([]models.ScoredRepo{}, scored...)
+		sort.SliceStable(byScore, func(i, j int) bool {
+			if byScore[i].Score != byScore[j].Score {
+				return byScore[i].Score > byScore[j].Score
+			}
+			return byScore[i].Key() < byScore[j].Key()
+		})
+		top := byScore
+		if len(top) > opts.TopN {
+			top = top[:opts.TopN]
+		}
+		retained := 0
+		for _, repo := range top {
+			if p.futureTop[strings.ToLower(repo.Key())] {
+				retained++
+			}
+		}
+		retentionSum += float64(retained) / float64(len(top))
+		retentionPeriods++
+
+		scores := make([]float64, len(scored))
+		outcomes := make([]float64, len(scored))
+		for i, repo := range scored {
+			scores[i] = float64(repo.Score)
+			outcomes[i] = float64(p.futureHeat30[strings.ToLower(repo.Key())])
+		}
+		if correlation, ok := spearman(scores, outcomes); ok {
+			correlationSum += correlation
+			correlationPeriods++
+		}
+
+		for rank, repo := range calc.RankRepositories(scored) {
+			if rank < opts.TopN || repo.Score < opts.DarkHorseThreshold {
+				continue
+			}
+			result.DarkHorses++
+			if p.futureTop[strings.ToLower(repo.Key())] {
+				result.DarkHorseHits++
+			}
+		}
+	}
+
+	if retentionPeriods > 0 {
+		result.Retention = retentionSum / float64(retentionPeriods)
+	}
+	if correlationPeriods > 0 {
+		result.RankCorrelation = correlationSum / float64(correlationPeriods)
+	}
+	if result.DarkHorses > 0 {
+		result.DarkHorseHitRate = float64(result.DarkHorseHits) / float64(result.DarkHorses)
+	}
+
+	return result
+}
+
+// spearman returns the Spearman rank correlation of x and y, or false when either is constant
+func spearman(x []float64, y []float64) (float64, bool) {
+	if len(x) < 2 {
+		return 0, false
+	}
+	return pearson(ranks(x), ranks(y))
+}
+
+// ranks returns the 1-based rank of each value, averaging the ranks of ties
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+
+	result := make([]float64, len(values))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && values[order[end]] == values[order[start]] {
+			end++
+		}
+		// Tied values share the average of positions start+1 .. end
+		average := float64(start+1+end) / 2
+		for _, index := range order[start:end] {
+			result[index] = average
+		}
+		start = end
+	}
+	return result
+}
+
+// pearson returns the Pearson correlation of x and y, or false when either is constant
+func pearson(x []float64, y []float64) (float64, bool) {
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+
+	var covariance, varianceX, varianceY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return 0, false
+	}
+	return covariance / math.Sqrt(varianceX*varianceY), true
+}
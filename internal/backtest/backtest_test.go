@@ -0,0 +1,94 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+func TestRanks(t *testing.T) {
+	got := ranks([]float64{10, 30, 10, 20})
+	expected := []float64{1.5, 4, 1.5, 3}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("ranks = %v, want %v", got, expected)
+		}
+	}
+}
+
+func TestSpearman(t *testing.T) {
+	tests := []struct {
+		name     string
+		x, y     []float64
+		expected float64
+		ok       bool
+	}{
+		{"same order", []float64{1, 2, 3}, []float64{10, 40, 90}, 1, true},
+		{"reversed", []float64{1, 2, 3}, []float64{5, 3, 1}, -1, true},
+		{"constant outcome", []float64{1, 2, 3}, []float64{0, 0, 0}, 0, false},
+		{"single value", []float64{1}, []float64{1}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := spearman(tt.x, tt.y)
+			if ok != tt.ok || math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("spearman = %f, %v; want %f, %v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	repo := func(name string, today, week, month int) models.ClassifiedRepo {
+		return models.ClassifiedRepo{Metadata: models.RepoMetadata{
+			Owner: "o", Name: name, StarsToday: today, StarsThisWeek: week, StarsThisMonth: month,
+		}}
+	}
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	snapshots := []Snapshot{
+		// "spike" leads today but fades; "steady" leads the month and keeps trending
+		{Date: day(1), Repos: []models.ClassifiedRepo{
+			repo("spike", 500, 600, 700),
+			repo("steady", 50, 1400, 6000),
+			repo("riser", 100, 700, 800),
+		}},
+		// Too close to day 1 to be its horizon, so day 1 pairs with day 8
+		{Date: day(3), Repos: []models.ClassifiedRepo{repo("steady", 60, 1500, 6100)}},
+		{Date: day(8), Repos: []models.ClassifiedRepo{
+			repo("steady", 70, 1600, 7000),
+			repo("riser", 150, 1500, 2500),
+		}},
+	}
+	candidates := []Candidate{
+		{Name: "today", Weights: config.ScoreWeights{Today: 1}},
+		{Name: "month", Weights: config.ScoreWeights{Month: 1}},
+	}
+
+	results := Run(snapshots, candidates, Options{TopN: 1, HorizonDays: 7, DarkHorseThreshold: 100})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	today, month := results[0], results[1]
+	if today.Periods != 1 || month.Periods != 1 {
+		t.Fatalf("expected 1 period each, got %d and %d", today.Periods, month.Periods)
+	}
+	if today.Retention != 0 {
+		t.Errorf("expected today-weighted top pick spike to drop out, got retention %f", today.Retention)
+	}
+	if month.Retention != 1 {
+		t.Errorf("expected month-weighted top pick steady to stay on top, got retention %f", month.Retention)
+	}
+	if month.RankCorrelation <= today.RankCorrelation {
+		t.Errorf("expected month weights to correlate better, got %f vs %f", month.RankCorrelation, today.RankCorrelation)
+	}
+	// Outside the top 1 by Heat_30, spike and riser reach a today-weighted score of 100
+	if today.DarkHorses != 2 || today.DarkHorseHits != 0 || today.DarkHorseHitRate != 0 {
+		t.Errorf("unexpected dark horses for today weights: %+v", today)
+	}
+}
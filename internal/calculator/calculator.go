@@ -2,7 +2,9 @@ package calculator
 
 import (
 	"sort"
+	"time"
 
+	"ai-repo-insights/internal/config"
 	"ai-repo-insights/internal/models"
 )
 
@@ -10,6 +12,32 @@ import (
 type ScoreCalculator struct {
 	windowDays      int
 	shortWindowDays int
+	weights         config.ScoreWeights
+
+	// Prior snapshots, newest first, used to estimate missing star windows
+	asOf   time.Time
+	priors []priorSnapshot
+
+	// constraints keep one category, owner or language from dominating the top N
+	constraints Constraints
+
+	// history holds the snapshots forecasts are fitted to, newest first
+	forecastAsOf time.Time
+	history      []priorSnapshot
+}
+
+// priorSnapshot is a saved snapshot indexed by lowercase repository key
+type priorSnapshot struct {
+	date  time.Time
+	repos map[string]models.RepoMetadata
+}
+
+// trendingWindowDays is the length of each trending window, which is also the
+// oldest a prior snapshot may be to stand in for it
+var trendingWindowDays = map[string]int{
+	models.WindowDaily:   1,
+	models.WindowWeekly:  7,
+	models.WindowMonthly: 30,
 }
 
 // New creates a new ScoreCalculator with the specified time windows
@@ -17,18 +45,68 @@ func New(windowDays int, shortWindowDays int) *ScoreCalculator {
 	return &ScoreCalculator{
 		windowDays:      windowDays,
 		shortWindowDays: shortWindowDays,
+		weights:         config.DefaultScoreWeights,
 	}
 }
 
+// WithWeights replaces the default score weights
+func (sc *ScoreCalculator) WithWeights(weights config.ScoreWeights) *ScoreCalculator {
+	sc.weights = weights
+	return sc
+}
+
+// WithPriorSnapshots estimates the star windows a repository is missing from using
+// snapshots taken before asOf. Snapshots must be ordered newest first.
+func (sc *ScoreCalculator) WithPriorSnapshots(asOf time.Time, snapshots []models.TrendingSnapshot) *ScoreCalculator {
+	sc.asOf = startOfDay(asOf)
+	sc.priors = indexSnapshots(snapshots)
+	return sc
+}
+
+// indexSnapshots indexes each snapshot's repositories by stable ID and lowercase key
+func indexSnapshots(snapshots []models.TrendingSnapshot) []priorSnapshot {
+	indexed := make([]priorSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		repos := make(map[string]models.RepoMetadata, len(snapshot.Repos))
+		for _, repo := range snapshot.Repos {
+			for _, key := range repo.MatchKeys() {
+				repos[key] = repo
+			}
+		}
+		indexed = append(indexed, priorSnapshot{date: snapshot.Date, repos: repos})
+	}
+	return indexed
+}
+
+// find looks a repository up by stable ID, falling back to its key for snapshots
+// saved before IDs were recorded or renamed since
+func (p priorSnapshot) find(repo models.RepoMetadata) (models.RepoMetadata, bool) {
+	for _, key := range repo.MatchKeys() {
+		if prior, exists := p.repos[key]; exists {
+			return prior, true
+		}
+	}
+	return models.RepoMetadata{}, false
+}
+
+// startOfDay truncates t to midnight UTC of its date, matching snapshot dates
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
 // CalculateScores calculates Heat_7, Heat_30, Acceleration for all repos
-// Uses stars gained from trending data (today, week, month)
+// Uses stars gained from trending data (today, week, month). A window the repo
+// was not listed on is estimated from prior snapshots when possible and otherwise
+// counts as 0, lowering the score's confidence.
 func (sc *ScoreCalculator) CalculateScores(repos []models.ClassifiedRepo) []models.ScoredRepo {
 	scoredRepos := make([]models.ScoredRepo, 0, len(repos))
 
 	for _, repo := range repos {
-		starsToday := repo.Metadata.StarsToday
-		starsThisWeek := repo.Metadata.StarsThisWeek
-		starsThisMonth := repo.Metadata.StarsThisMonth
+		var metrics models.MetricStatus
+		starsToday, todayStatus := sc.resolveWindow(repo.Metadata, models.WindowDaily)
+		starsThisWeek, weekStatus := sc.resolveWindow(repo.Metadata, models.WindowWeekly)
+		starsThisMonth, monthStatus := sc.resolveWindow(repo.Metadata, models.WindowMonthly)
+		metrics.Today, metrics.Week, metrics.Month = todayStatus, weekStatus, monthStatus
 		
 		// Heat_30: Use StarsThisMonth directly (GitHub's "month" is ~30 days)
 		heat30 := starsThisMonth
@@ -37,14 +115,15 @@ func (sc *ScoreCalculator) CalculateScores(repos []models.ClassifiedRepo) []mode
 		heat7 := starsThisWeek
 		
 		// Score: Weighted scoring combining short-term heat and sustained growth
-		// Formula: 0.6 × today + 0.3 × (week/7) + 0.1 × (month/30)
-		// Emphasizes recent activity (60%) while considering sustained trends (40%)
+		// Formula: w_today × today + w_week × (week/7) + w_month × (month/30)
+		// The default 0.6/0.3/0.1 weights emphasize recent activity (60%) while
+		// considering sustained trends (40%)
 		
 		dailyRate := float64(starsToday)
 		weeklyAvgRate := float64(starsThisWeek) / 7.0
 		monthlyAvgRate := float64(starsThisMonth) / 30.0
 		
-		scoreValue := dailyRate*0.6 + weeklyAvgRate*0.3 + monthlyAvgRate*0.1
+		scoreValue := dailyRate*sc.weights.Today + weeklyAvgRate*sc.weights.Week + monthlyAvgRate*sc.weights.Month
 		score := int(scoreValue)
 
 		scoredRepo := models.ScoredRepo{
@@ -54,6 +133,12 @@ func (sc *ScoreCalculator) CalculateScores(repos []models.ClassifiedRepo) []mode
 			Heat30:     heat30,
 			Prev30:     0,
 			Score:      score,
+			StarsToday: starsToday,
+			Metrics:    metrics,
+			Confidence: confidence(metrics),
+		}
+		if sc.history != nil {
+			scoredRepo.Forecast = sc.forecast(repo.Metadata)
 		}
 
 		scoredRepos = append(scoredRepos, scoredRepo)
@@ -62,21 +147,101 @@ func (sc *ScoreCalculator) CalculateScores(repos []models.ClassifiedRepo) []mode
 	return scoredRepos
 }
 
+// resolveWindow returns the stars gained in a trending window and whether the value
+// was observed, estimated from the newest prior snapshot that listed the repo on
+// that window, or missing
+func (sc *ScoreCalculator) resolveWindow(repo models.RepoMetadata, window string) (int, string) {
+	if repo.OnWindow(window) {
+		return windowStars(repo, window), models.MetricObserved
+	}
+
+	for _, prior := range sc.priors {
+		age := int(sc.asOf.Sub(prior.date).Hours() / 24)
+		if age < 1 {
+			continue
+		}
+		if age > trendingWindowDays[window] {
+			break
+		}
+		if priorRepo, exists := prior.find(repo); exists && priorRepo.OnWindow(window) {
+			return windowStars(priorRepo, window), models.MetricEstimated
+		}
+	}
+
+	return 0, models.MetricMissing
+}
+
+// windowStars returns the stars a repository gained in a trending window
+func windowStars(repo models.RepoMetadata, window string) int {
+	switch window {
+	case models.WindowDaily:
+		return repo.StarsToday
+	case models.WindowWeekly:
+		return repo.StarsThisWeek
+	case models.WindowMonthly:
+		return repo.StarsThisMonth
+	}
+	return 0
+}
+
+// confidence rates a score by the least reliable of its star windows
+func confidence(metrics models.MetricStatus) string {
+	level := models.ConfidenceHigh
+	for _, status := range []string{metrics.Today, metrics.Week, metrics.Month} {
+		switch status {
+		case models.MetricMissing:
+			return models.ConfidenceLow
+		case models.MetricEstimated:
+			level = models.ConfidenceMedium
+		}
+	}
+	return level
+}
+
 
 
 // RankAndSelectTop ranks repositories by (heat_30 desc, acceleration desc) and selects top N
+// Pinned repositories are always selected, displacing the lowest-ranked unpinned ones.
+// With constraints set, the selection also honours them (see selectConstrained).
 func (sc *ScoreCalculator) RankAndSelectTop(scoredRepos []models.ScoredRepo, topN int) []models.ScoredRepo {
 	// Sort by heat_30 descending, then by acceleration descending
 	ranked := sc.RankRepositories(scoredRepos)
+
+	if sc.constraints.active() {
+		return selectConstrained(ranked, topN, sc.constraints)
+	}
 	
 	// Select top N
-	if len(ranked) > topN {
-		return ranked[:topN]
+	if len(ranked) <= topN {
+		return ranked
 	}
-	return ranked
+
+	pinnedSlots := 0
+	for _, repo := range ranked {
+		if repo.Repo.Pinned {
+			pinnedSlots++
+		}
+	}
+	if pinnedSlots > topN {
+		pinnedSlots = topN
+	}
+	unpinnedSlots := topN - pinnedSlots
+
+	selected := make([]models.ScoredRepo, 0, topN)
+	for _, repo := range ranked {
+		if repo.Repo.Pinned && pinnedSlots > 0 {
+			selected = append(selected, repo)
+			pinnedSlots--
+		} else if !repo.Repo.Pinned && unpinnedSlots > 0 {
+			selected = append(selected, repo)
+			unpinnedSlots--
+		}
+	}
+
+	return selected
 }
 
-// RankRepositories sorts repositories by (heat_30 desc, acceleration desc)
+// RankRepositories sorts repositories by (heat_30 desc, score desc, key asc)
 func (sc *ScoreCalculator) RankRepositories(scoredRepos []models.ScoredRepo) []models.ScoredRepo {
 	// Create a copy to avoid modifying the input
 	ranked := make([]models.ScoredRepo, len(scoredRepos))
@@ -89,7 +254,11 @@ func (sc *ScoreCalculator) RankRepositories(scoredRepos []models.ScoredRepo) []m
 			return ranked[i].Heat30 > ranked[j].Heat30
 		}
 		// Secondary sort: score descending
-		return ranked[i].Score > ranked[j].Score
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		// Final tie-break: repository key, so equal repos keep a stable order across runs
+		return ranked[i].Key() < ranked[j].Key()
 	})
 	
 	return ranked
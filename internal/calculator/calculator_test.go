@@ -0,0 +1,272 @@
+package calculator
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"ai-repo-insights/internal/models"
+)
+
+func TestCalculateScores_WindowStatus(t *testing.T) {
+	asOf := time.Date(2024, 2, 14, 8, 0, 0, 0, time.UTC)
+	priors := []models.TrendingSnapshot{
+		{
+			Date: time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC),
+			Repos: []models.RepoMetadata{
+				{Owner: "a", Name: "estimated", StarsToday: 70, StarsThisWeek: 700,
+					TrendingWindows: []string{models.WindowDaily, models.WindowWeekly}},
+				{Owner: "old-owner", Name: "moved", ID: "R_moved", StarsThisWeek: 400,
+					TrendingWindows: []string{models.WindowWeekly}},
+			},
+		},
+		{
+			Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Repos: []models.RepoMetadata{
+				{Owner: "a", Name: "estimated", StarsThisMonth: 3000, TrendingWindows: []string{models.WindowMonthly}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		repo       models.RepoMetadata
+		priors     bool
+		metrics    models.MetricStatus
+		confidence string
+		heat7      int
+	}{
+		{
+			name: "all windows observed",
+			repo: models.RepoMetadata{Owner: "a", Name: "observed", StarsToday: 10, StarsThisWeek: 70, StarsThisMonth: 300,
+				TrendingWindows: []string{models.WindowDaily, models.WindowWeekly, models.WindowMonthly}},
+			metrics:    models.MetricStatus{Today: models.MetricObserved, Week: models.MetricObserved, Month: models.MetricObserved},
+			confidence: models.ConfidenceHigh,
+			heat7:      70,
+		},
+		{
+			name:       "missing windows without priors",
+			repo:       models.RepoMetadata{Owner: "a", Name: "estimated", StarsThisMonth: 2000, TrendingWindows: []string{models.WindowMonthly}},
+			metrics:    models.MetricStatus{Today: models.MetricMissing, Week: models.MetricMissing, Month: models.MetricObserved},
+			confidence: models.ConfidenceLow,
+			heat7:      0,
+		},
+		{
+			name:       "missing windows estimated from priors",
+			repo:       models.RepoMetadata{Owner: "A", Name: "Estimated", StarsThisMonth: 2000, TrendingWindows: []string{models.WindowMonthly}},
+			priors:     true,
+			metrics:    models.MetricStatus{Today: models.MetricEstimated, Week: models.MetricEstimated, Month: models.MetricObserved},
+			confidence: models.ConfidenceMedium,
+			heat7:      700,
+		},
+		{
+			name:       "transferred repository matched by ID",
+			repo:       models.RepoMetadata{Owner: "new-owner", Name: "moved", ID: "R_moved", StarsToday: 60, TrendingWindows: []string{models.WindowDaily}},
+			priors:     true,
+			metrics:    models.MetricStatus{Today: models.MetricObserved, Week: models.MetricEstimated, Month: models.MetricMissing},
+			confidence: models.ConfidenceLow,
+			heat7:      400,
+		},
+		{
+			name:       "prior too old for the window",
+			repo:       models.RepoMetadata{Owner: "a", Name: "estimated", StarsToday: 5, StarsThisWeek: 50, TrendingWindows: []string{models.WindowDaily, models.WindowWeekly}},
+			priors:     true,
+			metrics:    models.MetricStatus{Today: models.MetricObserved, Week: models.MetricObserved, Month: models.MetricMissing},
+			confidence: models.ConfidenceLow,
+			heat7:      50,
+		},
+		{
+			name:       "legacy snapshot without recorded windows",
+			repo:       models.RepoMetadata{Owner: "a", Name: "legacy", StarsToday: 3, StarsThisWeek: 20, StarsThisMonth: 90},
+			metrics:    models.MetricStatus{Today: models.MetricObserved, Week: models.MetricObserved, Month: models.MetricObserved},
+			confidence: models.ConfidenceHigh,
+			heat7:      20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := New(30, 7)
+			if tt.priors {
+				calc.WithPriorSnapshots(asOf, priors)
+			}
+
+			scored := calc.CalculateScores([]models.ClassifiedRepo{{Metadata: tt.repo}})
+			if len(scored) != 1 {
+				t.Fatalf("expected 1 scored repo, got %d", len(scored))
+			}
+			if scored[0].Metrics != tt.metrics {
+				t.Errorf("metrics = %+v, want %+v", scored[0].Metrics, tt.metrics)
+			}
+			if scored[0].Confidence != tt.confidence {
+				t.Errorf("confidence = %q, want %q", scored[0].Confidence, tt.confidence)
+			}
+			if scored[0].Heat7 != tt.heat7 {
+				t.Errorf("heat_7 = %d, want %d", scored[0].Heat7, tt.heat7)
+			}
+		})
+	}
+}
+
+func TestRankAndSelectTop_Constraints(t *testing.T) {
+	repo := func(owner, name, category, language string, heat30 int) models.ScoredRepo {
+		return models.ScoredRepo{
+			Repo: models.ClassifiedRepo{
+				Metadata:        models.RepoMetadata{Owner: owner, Name: name, Language: language},
+				PrimaryCategory: category,
+			},
+			Heat30: heat30,
+		}
+	}
+	repos := []models.ScoredRepo{
+		repo("big", "a1", "agent", "python", 100),
+		repo("big", "a2", "agent", "python", 90),
+		repo("Big", "a3", "agent", "python", 80),
+		repo("x", "a4", "agent", "python", 70),
+		repo("y", "r1", "rag", "python", 60),
+		repo("z", "t1", "tools", "go", 10),
+	}
+
+	tests := []struct {
+		name        string
+		constraints Constraints
+		topN        int
+		expected    []string
+	}{
+		{
+			name:     "no constraints",
+			topN:     3,
+			expected: []string{"big/a1", "big/a2", "Big/a3"},
+		},
+		{
+			name:        "max per owner is case-insensitive",
+			constraints: Constraints{MaxPerOwner: 2},
+			topN:        4,
+			expected:    []string{"big/a1", "big/a2", "x/a4", "y/r1"},
+		},
+		{
+			name:        "max per category",
+			constraints: Constraints{MaxPerCategory: 2},
+			topN:        4,
+			expected:    []string{"big/a1", "big/a2", "y/r1", "z/t1"},
+		},
+		{
+			name:        "caps can leave the table short",
+			constraints: Constraints{MaxPerCategory: 1},
+			topN:        5,
+			expected:    []string{"big/a1", "y/r1", "z/t1"},
+		},
+		{
+			name:        "min per category reserves slots in rank order",
+			constraints: Constraints{MinPerCategory: 1},
+			topN:        3,
+			expected:    []string{"big/a1", "y/r1", "z/t1"},
+		},
+		{
+			name:        "min per language",
+			constraints: Constraints{MinPerLanguage: 1},
+			topN:        2,
+			expected:    []string{"big/a1", "z/t1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected := New(30, 7).WithConstraints(tt.constraints).RankAndSelectTop(repos, tt.topN)
+
+			var keys []string
+			for _, repo := range selected {
+				keys = append(keys, repo.Key())
+			}
+			if strings.Join(keys, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("selected %v, want %v", keys, tt.expected)
+			}
+		})
+	}
+
+	t.Run("pinned repositories ignore caps", func(t *testing.T) {
+		pinned := append([]models.ScoredRepo{}, repos...)
+		pinned[3].Repo.Pinned = true
+
+		selected := New(30, 7).WithConstraints(Constraints{MaxPerCategory: 1}).RankAndSelectTop(pinned, 3)
+		var keys []string
+		for _, repo := range selected {
+			keys = append(keys, repo.Key())
+		}
+		if got := strings.Join(keys, ","); got != "x/a4,y/r1,z/t1" {
+			t.Errorf("selected %s, want pinned x/a4 to take the only agent slot", got)
+		}
+	})
+}
+
+func TestCalculateScores_Forecast(t *testing.T) {
+	asOf := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	daily := []string{models.WindowDaily}
+	snapshot := func(daysAgo int, repos ...models.RepoMetadata) models.TrendingSnapshot {
+		return models.TrendingSnapshot{Date: asOf.AddDate(0, 0, -daysAgo), Repos: repos}
+	}
+	rising := func(stars int) models.RepoMetadata {
+		return models.RepoMetadata{Owner: "o", Name: "rising", StarsToday: stars, TrendingWindows: daily}
+	}
+	// Daily gains double every week
+	history := []models.TrendingSnapshot{
+		snapshot(7, rising(200)),
+		snapshot(14, rising(100)),
+		snapshot(21, rising(50)),
+		snapshot(60, rising(1)),
+	}
+
+	calc := New(30, 7).WithForecast(asOf, history, 28)
+	scored := calc.CalculateScores([]models.ClassifiedRepo{
+		{Metadata: rising(400)},
+		{Metadata: models.RepoMetadata{Owner: "o", Name: "new", StarsToday: 50, TrendingWindows: daily}},
+	})
+
+	forecast := scored[0].Forecast
+	if forecast == nil {
+		t.Fatal("expected a forecast for a repository with four snapshots")
+	}
+	if forecast.Points != 4 {
+		t.Errorf("expected the 60-day-old snapshot outside the lookback to be ignored, got %d points", forecast.Points)
+	}
+	if forecast.DailyGrowth < 0.09 || forecast.DailyGrowth > 0.11 {
+		t.Errorf("expected about 10%% daily growth for weekly doubling, got %f", forecast.DailyGrowth)
+	}
+	if forecast.Stars <= 7*400 || forecast.Low > forecast.Stars || forecast.High < forecast.Stars {
+		t.Errorf("expected growing projection inside its band, got %+v", forecast)
+	}
+
+	if scored[1].Forecast != nil {
+		t.Errorf("expected no forecast without history, got %+v", scored[1].Forecast)
+	}
+}
+
+func TestCalculateScores_ForecastUsesOneWindow(t *testing.T) {
+	asOf := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	steady := func(windows ...string) models.RepoMetadata {
+		return models.RepoMetadata{Owner: "o", Name: "steady", StarsToday: 1000, StarsThisWeek: 700, TrendingWindows: windows}
+	}
+	history := []models.TrendingSnapshot{
+		{Date: asOf.AddDate(0, 0, -7), Repos: []models.RepoMetadata{steady(models.WindowWeekly)}},
+		{Date: asOf.AddDate(0, 0, -14), Repos: []models.RepoMetadata{steady(models.WindowWeekly)}},
+		{Date: asOf.AddDate(0, 0, -21), Repos: []models.RepoMetadata{steady(models.WindowWeekly)}},
+	}
+
+	// A daily spike today must not be read against last weeks' weekly averages
+	calc := New(30, 7).WithForecast(asOf, history, 28)
+	scored := calc.CalculateScores([]models.ClassifiedRepo{
+		{Metadata: steady(models.WindowDaily, models.WindowWeekly)},
+	})
+
+	forecast := scored[0].Forecast
+	if forecast == nil {
+		t.Fatal("expected a forecast from four weekly observations")
+	}
+	if forecast.Points != 4 || math.Abs(forecast.DailyGrowth) > 0.001 {
+		t.Errorf("expected a flat forecast from the weekly average alone, got %+v", forecast)
+	}
+	if forecast.Stars != 7*100 {
+		t.Errorf("expected 700 projected stars, got %d", forecast.Stars)
+	}
+}
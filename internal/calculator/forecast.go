@@ -0,0 +1,159 @@
+package calculator
+
+import (
+	"math"
+	"time"
+
+	"ai-repo-insights/internal/models"
+)
+
+const (
+	// forecastDays is how many days ahead forecasts project
+	forecastDays = 7
+	// minForecastPoints is the fewest snapshots a repository needs to be forecast
+	minForecastPoints = 3
+	// forecastHalfLifeDays halves the weight of an observation every this many days of age
+	forecastHalfLifeDays = 7.0
+	// maxDailyGrowth caps the fitted growth rate so short bursts do not explode
+	maxDailyGrowth = 0.2
+	// minForecastSpread is the smallest log-scale uncertainty, about ±15%
+	minForecastSpread = 0.15
+)
+
+// WithForecast fits a star forecast for each repository to the snapshots taken within
+// lookbackDays before asOf. Snapshots must be ordered newest first.
+func (sc *ScoreCalculator) WithForecast(asOf time.Time, snapshots []models.TrendingSnapshot, lookbackDays int) *ScoreCalculator {
+	sc.forecastAsOf = startOfDay(asOf)
+	oldest := sc.forecastAsOf.AddDate(0, 0, -lookbackDays)
+
+	var recent []models.TrendingSnapshot
+	for _, snapshot := range snapshots {
+		if !snapshot.Date.Before(oldest) {
+			recent = append(recent, snapshot)
+		}
+	}
+	sc.history = indexSnapshots(recent)
+	return sc
+}
+
+// forecastPoint is one observed daily star rate
+type forecastPoint struct {
+	day  float64
+	rate float64
+}
+
+// forecastWindows are the trending windows a forecast can take its rates from,
+// preferred in this order when a repository appears on several equally often
+var forecastWindows = []string{models.WindowDaily, models.WindowWeekly, models.WindowMonthly}
+
+// forecast projects the stars repo gains over the next forecastDays. All points come
+// from one trending window, the one the repository appeared on in the most snapshots,
+// so stars today are never mixed with weekly or monthly averages. A line is fitted to
+// ln(1 + rate) by least squares weighted by recency, so the projection grows or decays
+// exponentially. Returns nil with fewer than minForecastPoints snapshots on that window.
+func (sc *ScoreCalculator) forecast(repo models.RepoMetadata) *models.Forecast {
+	records := []models.RepoMetadata{repo}
+	days := []float64{0}
+	for _, snapshot := range sc.history {
+		age := sc.forecastAsOf.Sub(snapshot.date).Hours() / 24
+		if age < 1 {
+			continue
+		}
+		if prior, exists := snapshot.find(repo); exists {
+			records = append(records, prior)
+			days = append(days, -age)
+		}
+	}
+
+	window, best := "", 0
+	for _, w := range forecastWindows {
+		count := 0
+		for i := range records {
+			if records[i].OnWindow(w) {
+				count++
+			}
+		}
+		if count > best {
+			window, best = w, count
+		}
+	}
+	if best < minForecastPoints {
+		return nil
+	}
+
+	points := make([]forecastPoint, 0, best)
+	for i := range records {
+		if records[i].OnWindow(window) {
+			points = append(points, forecastPoint{day: days[i], rate: dailyRate(records[i], window)})
+		}
+	}
+
+	intercept, slope, spread := fitLogLinear(points)
+	slope = math.Max(-maxDailyGrowth, math.Min(maxDailyGrowth, slope))
+	spread = math.Max(spread, minForecastSpread)
+
+	return &models.Forecast{
+		Days:        forecastDays,
+		Stars:       projectStars(intercept, slope),
+		Low:         projectStars(intercept-spread, slope),
+		High:        projectStars(intercept+spread, slope),
+		DailyGrowth: math.Exp(slope) - 1,
+		Points:      len(points),
+	}
+}
+
+// dailyRate returns the daily star rate of a snapshot record on a trending window
+func dailyRate(repo models.RepoMetadata, window string) float64 {
+	switch window {
+	case models.WindowWeekly:
+		return float64(repo.StarsThisWeek) / 7
+	case models.WindowMonthly:
+		return float64(repo.StarsThisMonth) / 30
+	}
+	return float64(repo.StarsToday)
+}
+
+// fitLogLinear fits ln(1 + rate) = intercept + slope × day, weighting each point by
+// 0.5^(age / forecastHalfLifeDays), and returns the weighted residual standard deviation
+func fitLogLinear(points []forecastPoint) (intercept float64, slope float64, spread float64) {
+	var sumWeight, meanDay, meanValue float64
+	weights := make([]float64, len(points))
+	values := make([]float64, len(points))
+	for i, p := range points {
+		weights[i] = math.Pow(0.5, -p.day/forecastHalfLifeDays)
+		values[i] = math.Log1p(p.rate)
+		sumWeight += weights[i]
+		meanDay += weights[i] * p.day
+		meanValue += weights[i] * values[i]
+	}
+	meanDay /= sumWeight
+	meanValue /= sumWeight
+
+	var covariance, variance float64
+	for i, p := range points {
+		covariance += weights[i] * (p.day - meanDay) * (values[i] - meanValue)
+		variance += weights[i] * (p.day - meanDay) * (p.day - meanDay)
+	}
+	if variance > 0 {
+		slope = covariance / variance
+	}
+	intercept = meanValue - slope*meanDay
+
+	var residuals float64
+	for i, p := range points {
+		r := values[i] - (intercept + slope*p.day)
+		residuals += weights[i] * r * r
+	}
+	spread = math.Sqrt(residuals / sumWeight)
+
+	return intercept, slope, spread
+}
+
+// projectStars sums the fitted daily rates over the next forecastDays
+func projectStars(intercept float64, slope float64) int {
+	var total float64
+	for day := 1; day <= forecastDays; day++ {
+		total += math.Max(0, math.Expm1(intercept+slope*float64(day)))
+	}
+	return int(math.Round(total))
+}
@@ -0,0 +1,125 @@
+package calculator
+
+import (
+	"strings"
+
+	"ai-repo-insights/internal/models"
+)
+
+// Constraints limits how much of the top N one category, owner or language can take.
+// A zero value disables the constraint.
+type Constraints struct {
+	MaxPerCategory int
+	MaxPerOwner    int
+	MinPerCategory int
+	MinPerLanguage int
+}
+
+// active reports whether any constraint is set
+func (c Constraints) active() bool {
+	return c.MaxPerCategory > 0 || c.MaxPerOwner > 0 || c.MinPerCategory > 0 || c.MinPerLanguage > 0
+}
+
+// WithConstraints makes RankAndSelectTop honour diversity constraints
+func (sc *ScoreCalculator) WithConstraints(constraints Constraints) *ScoreCalculator {
+	sc.constraints = constraints
+	return sc
+}
+
+// selection tracks the repositories chosen so far and their group counts
+type selection struct {
+	constraints Constraints
+	chosen      []bool
+	count       int
+	categories  map[string]int
+	owners      map[string]int
+	languages   map[string]int
+}
+
+// selectConstrained picks up to topN repositories from ranked in three greedy passes:
+//  1. pinned repositories, which count toward caps but are never dropped by them
+//  2. the best repositories of each category and language still below its minimum,
+//     visited in rank order so higher-ranked groups are served first
+//  3. the remaining repositories in rank order
+//
+// Passes 2 and 3 skip a repository whose category or owner is already at its cap,
+// so the table can come out shorter than topN. The result keeps rank order.
+func selectConstrained(ranked []models.ScoredRepo, topN int, constraints Constraints) []models.ScoredRepo {
+	s := &selection{
+		constraints: constraints,
+		chosen:      make([]bool, len(ranked)),
+		categories:  make(map[string]int),
+		owners:      make(map[string]int),
+		languages:   make(map[string]int),
+	}
+
+	for i, repo := range ranked {
+		if repo.Repo.Pinned && s.count < topN {
+			s.add(i, repo)
+		}
+	}
+
+	if constraints.MinPerCategory > 0 || constraints.MinPerLanguage > 0 {
+		for i, repo := range ranked {
+			if s.count == topN {
+				break
+			}
+			if !s.chosen[i] && s.belowMinimum(repo) && s.withinCaps(repo) {
+				s.add(i, repo)
+			}
+		}
+	}
+
+	for i, repo := range ranked {
+		if s.count == topN {
+			break
+		}
+		if !s.chosen[i] && s.withinCaps(repo) {
+			s.add(i, repo)
+		}
+	}
+
+	selected := make([]models.ScoredRepo, 0, s.count)
+	for i, repo := range ranked {
+		if s.chosen[i] {
+			selected = append(selected, repo)
+		}
+	}
+	return selected
+}
+
+// add selects the repository at index i
+func (s *selection) add(i int, repo models.ScoredRepo) {
+	s.chosen[i] = true
+	s.count++
+	s.categories[repo.Repo.PrimaryCategory]++
+	s.owners[ownerKey(repo)]++
+	s.languages[repo.Repo.Metadata.Language]++
+}
+
+// withinCaps reports whether selecting repo keeps its category and owner within their caps
+func (s *selection) withinCaps(repo models.ScoredRepo) bool {
+	if s.constraints.MaxPerCategory > 0 && s.categories[repo.Repo.PrimaryCategory] >= s.constraints.MaxPerCategory {
+		return false
+	}
+	if s.constraints.MaxPerOwner > 0 && s.owners[ownerKey(repo)] >= s.constraints.MaxPerOwner {
+		return false
+	}
+	return true
+}
+
+// belowMinimum reports whether repo's category or language has not reached its minimum.
+// Uncategorized repositories and repositories without a language have no minimum.
+func (s *selection) belowMinimum(repo models.ScoredRepo) bool {
+	category := repo.Repo.PrimaryCategory
+	if category != "" && s.categories[category] < s.constraints.MinPerCategory {
+		return true
+	}
+	language := repo.Repo.Metadata.Language
+	return language != "" && s.languages[language] < s.constraints.MinPerLanguage
+}
+
+// ownerKey returns the case-insensitive owner of a repository
+func ownerKey(repo models.ScoredRepo) string {
+	return strings.ToLower(repo.Repo.Metadata.Owner)
+}
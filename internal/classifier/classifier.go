@@ -1,175 +1,478 @@
 package classifier
 
 import (
+	"fmt"
 	"strings"
 
 	"ai-repo-insights/internal/config"
 	"ai-repo-insights/internal/models"
+	"ai-repo-insights/internal/rules"
+	"ai-repo-insights/internal/textutil"
 )
 
+// RepoClassifier filters and categorizes repositories. The keyword Classifier and
+// the EmbeddingClassifier both implement it.
+type RepoClassifier interface {
+	Classify(repos []models.RepoMetadata) []models.ClassifiedRepo
+}
+
 // Classifier filters and categorizes repositories based on keyword rules
 type Classifier struct {
-	keywords config.KeywordConfig
+	keywords      config.KeywordConfig
+	categoryOrder []string
+	fieldWeights  config.FieldWeights
+	includeRules  []rules.Expr
+	excludeRules  []rules.Expr
+	categoryRules map[string][]rules.Expr
+	synonyms      map[string][]string
+	overrides     map[string]config.RepoOverride
 }
 
-// New creates a new Classifier with the given keyword configuration
+// New creates a new Classifier with the given keyword configuration.
+// Rule expressions are compiled once here; expressions that fail to parse are
+// skipped (Config.Validate reports them with positions).
 func New(keywords config.KeywordConfig) *Classifier {
+	categoryRules := make(map[string][]rules.Expr, len(keywords.CategoryRules))
+	for category, expressions := range keywords.CategoryRules {
+		categoryRules[category] = compileRules(expressions)
+	}
+
 	return &Classifier{
-		keywords: keywords,
+		keywords:      keywords,
+		categoryOrder: keywords.OrderedCategories(),
+		fieldWeights:  keywords.FieldWeights.WithDefaults(),
+		includeRules:  compileRules(keywords.IncludeRules),
+		excludeRules:  compileRules(keywords.ExcludeRules),
+		categoryRules: categoryRules,
+		synonyms:      buildSynonyms(keywords.Synonyms),
+	}
+}
+
+// buildSynonyms maps each normalized term to every term in its synonym groups
+func buildSynonyms(groups [][]string) map[string][]string {
+	synonyms := make(map[string][]string)
+	for _, group := range groups {
+		for _, term := range group {
+			key := normalizeTerm(term)
+			for _, synonym := range group {
+				if normalized := normalizeTerm(synonym); normalized != "" && !containsCategory(synonyms[key], normalized) {
+					synonyms[key] = append(synonyms[key], normalized)
+				}
+			}
+		}
+	}
+	return synonyms
+}
+
+// normalizeTerm lowercases a term and unifies hyphen, underscore and space variants
+func normalizeTerm(term string) string {
+	if textutil.ContainsCJK(term) {
+		return strings.ToLower(strings.TrimSpace(term))
 	}
+	return strings.Join(textutil.Words(term), " ")
+}
+
+// WithOverrides sets per-repository curation overrides, keyed by lowercase owner/repo
+func (c *Classifier) WithOverrides(overrides map[string]config.RepoOverride) *Classifier {
+	c.overrides = overrides
+	return c
 }
 
 // Classify filters and categorizes repositories based on keyword rules
-// Returns only repositories that pass the filter (match include, don't match exclude)
+// Returns only repositories that pass the filter (match include, don't match exclude,
+// and reach the configured minimum match score) or that an override includes
 func (c *Classifier) Classify(repos []models.RepoMetadata) []models.ClassifiedRepo {
 	var classified []models.ClassifiedRepo
 
 	for _, repo := range repos {
-		if c.matchesInclude(repo) && !c.matchesExclude(repo) {
-			categories := c.assignCategories(repo)
-			primaryCategory := c.selectPrimaryCategory(repo, categories)
-			matchScore := c.calculateMatchScore(repo)
-
-			classified = append(classified, models.ClassifiedRepo{
-				Metadata:        repo,
-				Categories:      categories,
-				PrimaryCategory: primaryCategory,
-				MatchScore:      matchScore,
-			})
+		trace := c.Explain(repo)
+		if trace.Included {
+			classified = append(classified, c.newClassifiedRepo(repo, trace))
 		}
 	}
 
 	return classified
 }
 
-// matchesInclude checks if the repository matches any include keyword
+// newClassifiedRepo builds the classification result for an included repository
+func (c *Classifier) newClassifiedRepo(repo models.RepoMetadata, trace models.ClassificationTrace) models.ClassifiedRepo {
+	var categories []string
+	for _, category := range trace.Categories {
+		categories = append(categories, category.Name)
+	}
+	if trace.PrimaryCategory != "" && !containsCategory(categories, trace.PrimaryCategory) {
+		categories = append([]string{trace.PrimaryCategory}, categories...)
+	}
+
+	override := c.overrides[strings.ToLower(repo.Key())]
+	return models.ClassifiedRepo{
+		Metadata:        repo,
+		Categories:      categories,
+		PrimaryCategory: trace.PrimaryCategory,
+		MatchScore:      trace.MatchScore,
+		ContentType:     trace.ContentType,
+		DisplayName:     override.DisplayName,
+		CuratorNote:     override.Note,
+		Pinned:          override.Pin,
+		Trace:           &trace,
+	}
+}
+
+// containsCategory reports whether name is in categories
+func containsCategory(categories []string, name string) bool {
+	for _, category := range categories {
+		if category == name {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesInclude checks if the repository matches any include keyword.
+// README matches only count when ReadmeCountsForInclude is enabled.
 func (c *Classifier) matchesInclude(repo models.RepoMetadata) bool {
-	searchText := c.buildSearchText(repo)
+	fields := c.buildFieldTexts(repo)
 
 	for _, keyword := range c.keywords.Include {
-		if c.matchesKeyword(searchText, keyword) {
+		if c.matchesKeyword(fields.all, keyword) {
+			return true
+		}
+		if c.keywords.ReadmeCountsForInclude && fields.readme != "" && c.matchesKeyword(fields.readme, keyword) {
 			return true
 		}
 	}
 
-	return false
+	return c.matchesAnyRule(fields, c.includeRules)
 }
 
-// matchesExclude checks if the repository matches any exclude keyword
+// matchesExclude checks if the repository matches any exclude keyword.
+// READMEs are not consulted: they routinely mention tutorials, courses and books.
 func (c *Classifier) matchesExclude(repo models.RepoMetadata) bool {
-	searchText := c.buildSearchText(repo)
+	return c.excludedBy(c.buildFieldTexts(repo)) != ""
+}
+
+// excludedBy returns the first exclude keyword or rule that matches, or ""
+func (c *Classifier) excludedBy(fields fieldTexts) string {
+	for _, keyword := range c.keywords.Exclude {
+		if c.matchesExcludeKeyword(fields.all, keyword) {
+			return fmt.Sprintf("keyword %q", keyword)
+		}
+	}
+
+	if matched := c.matchingRules(fields, c.excludeRules); len(matched) > 0 {
+		return fmt.Sprintf("rule %q", matched[0])
+	}
+
+	return ""
+}
 
+// CoversTerm reports whether an include, exclude or category keyword already matches term
+func (c *Classifier) CoversTerm(term string) bool {
+	for _, keyword := range c.keywords.Include {
+		if c.matchesKeyword(term, keyword) {
+			return true
+		}
+	}
 	for _, keyword := range c.keywords.Exclude {
-		if c.matchesKeyword(searchText, keyword) {
+		if c.matchesExcludeKeyword(term, keyword) {
 			return true
 		}
 	}
+	return c.CoversCategoryTerm(term)
+}
 
+// CoversCategoryTerm reports whether a category keyword already matches term
+func (c *Classifier) CoversCategoryTerm(term string) bool {
+	for _, categoryName := range c.categoryOrder {
+		for _, keyword := range c.keywords.Categories[categoryName] {
+			if c.matchesKeyword(term, keyword) {
+				return true
+			}
+		}
+	}
 	return false
 }
 
-// matchesKeyword checks if a keyword matches with word boundaries
+// wordMatcher compares a single text word with a keyword word
+type wordMatcher func(word string, keywordWord string, allowPrefix bool) bool
+
+// matchesKeyword checks if a keyword or any of its synonyms matches with word boundaries
 func (c *Classifier) matchesKeyword(text string, keyword string) bool {
-	keyword = strings.ToLower(keyword)
+	return c.matchKeyword(text, keyword, matchesWord)
+}
+
+// matchesExcludeKeyword checks if an exclude keyword or any of its synonyms matches.
+// Exclude keywords match words exactly or as plurals, never by stem or prefix, so
+// "learning" does not exclude "learns" or "learned".
+func (c *Classifier) matchesExcludeKeyword(text string, keyword string) bool {
+	return c.matchKeyword(text, keyword, matchesPlural)
+}
+
+// matchKeyword checks if a keyword or any of its synonyms matches text, comparing
+// words with match
+func (c *Classifier) matchKeyword(text string, keyword string, match wordMatcher) bool {
 	text = strings.ToLower(text)
-	
-	// For hyphenated keywords like "machine-learning", match as-is
-	if strings.Contains(keyword, "-") {
-		return strings.Contains(text, keyword)
-	}
-	
-	// For single words, check if keyword appears as whole word or as part of related words
-	// This handles: exact match, plurals, and word stems (e.g., "agent" matches "agentic")
-	words := strings.Fields(text)
-	for _, word := range words {
-		// Remove common punctuation
-		word = strings.Trim(word, ".,;:!?()[]{}\"'")
-		
-		// Exact match or plural match
-		if word == keyword || word == keyword+"s" {
+	keyword = normalizeTerm(keyword)
+
+	variants := c.synonyms[keyword]
+	if len(variants) == 0 {
+		variants = []string{keyword}
+	}
+
+	var tokens, words []string
+	for _, variant := range variants {
+		// CJK text has no spaces between words, so CJK keywords match as substrings
+		if textutil.ContainsCJK(variant) {
+			if strings.Contains(text, variant) {
+				return true
+			}
+			continue
+		}
+
+		keywordWords := strings.Fields(variant)
+		if len(keywordWords) == 1 {
+			// A single word matches whole tokens unless compound parts are enabled: a
+			// hyphenated word names something else, so "learning" does not match
+			// "machine-learning"
+			if tokens == nil {
+				if c.keywords.MatchCompoundParts {
+					tokens = textutil.Words(text)
+				} else {
+					tokens = textutil.Tokenize(text)
+				}
+			}
+			for _, token := range tokens {
+				if match(token, keywordWords[0], true) {
+					return true
+				}
+			}
+			continue
+		}
+
+		if words == nil {
+			words = textutil.Words(text)
+		}
+		if matchesWords(words, keywordWords, match) {
 			return true
 		}
-		
-		// Stem matching: if word starts with keyword (handles "agentic" from "agent")
-		// Only for keywords >= 4 chars to avoid false positives
-		if len(keyword) >= 4 && strings.HasPrefix(word, keyword) {
+	}
+
+	return false
+}
+
+// matchesWords checks if the words of a multi-word keyword appear consecutively in the
+// text words. Hyphen, underscore and space variants ("machine-learning", "machine
+// learning") are already unified by textutil.Words; each word is compared with match.
+func matchesWords(words []string, keywordWords []string, match wordMatcher) bool {
+	if len(keywordWords) == 0 {
+		return false
+	}
+
+	for start := 0; start+len(keywordWords) <= len(words); start++ {
+		matched := true
+		for i, keywordWord := range keywordWords {
+			if !match(words[start+i], keywordWord, false) {
+				matched = false
+				break
+			}
+		}
+		if matched {
 			return true
 		}
 	}
-	
+
 	return false
 }
 
-// assignCategories assigns all matching categories to the repository
+// matchesWord compares a single text word with a keyword word
+func matchesWord(word string, keywordWord string, allowPrefix bool) bool {
+	if word == keywordWord || textutil.Stem(word) == textutil.Stem(keywordWord) {
+		return true
+	}
+
+	// Stem matching: if word starts with keyword (handles "agentic" from "agent")
+	// Only for single-word keywords >= 4 chars to avoid false positives
+	return allowPrefix && len(keywordWord) >= 4 && strings.HasPrefix(word, keywordWord)
+}
+
+// matchesPlural compares a single text word with a keyword word, allowing only plural
+// endings to differ; allowPrefix is ignored
+func matchesPlural(word string, keywordWord string, allowPrefix bool) bool {
+	return word == keywordWord || textutil.Singular(word) == textutil.Singular(keywordWord)
+}
+
+// assignCategories assigns all matching categories to the repository, in priority order
 func (c *Classifier) assignCategories(repo models.RepoMetadata) []string {
-	searchText := c.buildSearchText(repo)
+	fields := c.buildFieldTexts(repo)
 	var categories []string
 
-	for categoryName, keywords := range c.keywords.Categories {
+	for _, categoryName := range c.categoryOrder {
+		keywords := c.keywords.Categories[categoryName]
+		matched := c.matchesAnyRule(fields, c.categoryRules[categoryName])
 		for _, keyword := range keywords {
-			if c.matchesKeyword(searchText, keyword) {
-				categories = append(categories, categoryName)
+			if matched {
 				break // Only add category once even if multiple keywords match
 			}
+			matched = c.keywordFieldWeight(fields, keyword) > 0
+		}
+		if matched {
+			categories = append(categories, categoryName)
 		}
 	}
 
 	return categories
 }
 
-// selectPrimaryCategory selects the primary category based on keyword match frequency
+// selectPrimaryCategory selects the category with the highest weighted keyword score.
+// Ties go to the category that comes first in priority order.
 func (c *Classifier) selectPrimaryCategory(repo models.RepoMetadata, categories []string) string {
+	primaryCategory, _ := c.pickPrimaryCategory(c.buildFieldTexts(repo), categories)
+	return primaryCategory
+}
+
+// pickPrimaryCategory returns the highest-scoring category and, when several
+// categories share the top score, a description of how the tie was broken
+func (c *Classifier) pickPrimaryCategory(fields fieldTexts, categories []string) (string, string) {
 	if len(categories) == 0 {
-		return ""
+		return "", ""
 	}
 
-	searchText := c.buildSearchText(repo)
-	maxMatches := 0
-	primaryCategory := categories[0] // Default to first category
+	scores := make([]float64, len(categories))
+	maxScore := 0.0
+	primaryCategory := categories[0] // Default to highest-priority category
 
-	for _, categoryName := range categories {
-		matches := 0
-		keywords := c.keywords.Categories[categoryName]
+	for i, categoryName := range categories {
+		scores[i] = c.categoryScore(fields, categoryName)
 
-		for _, keyword := range keywords {
-			if c.matchesKeyword(searchText, keyword) {
-				matches++
-			}
+		if scores[i] > maxScore {
+			maxScore = scores[i]
+			primaryCategory = categoryName
 		}
+	}
 
-		if matches > maxMatches {
-			maxMatches = matches
-			primaryCategory = categoryName
+	var tied []string
+	for i, categoryName := range categories {
+		if scores[i] == maxScore {
+			tied = append(tied, categoryName)
 		}
 	}
+	if len(tied) < 2 {
+		return primaryCategory, ""
+	}
 
-	return primaryCategory
+	return primaryCategory, fmt.Sprintf("%s tied at score %.2f; chose %s by category priority",
+		strings.Join(tied, ", "), maxScore, primaryCategory)
 }
 
-// calculateMatchScore counts the total number of keyword matches
-func (c *Classifier) calculateMatchScore(repo models.RepoMetadata) int {
-	searchText := c.buildSearchText(repo)
-	score := 0
+// categoryScore sums the weighted keyword and rule matches of a single category
+func (c *Classifier) categoryScore(fields fieldTexts, categoryName string) float64 {
+	score := 0.0
+	for _, keyword := range c.keywords.Categories[categoryName] {
+		score += c.keywordScore(fields, keyword)
+	}
+	score += c.rulesScore(fields, c.categoryRules[categoryName])
+	return score
+}
+
+// calculateMatchScore sums weighted matches across include and category keywords and rules.
+// Each match scores its keyword weight times the weight of the strongest matching field.
+func (c *Classifier) calculateMatchScore(repo models.RepoMetadata) float64 {
+	fields := c.buildFieldTexts(repo)
+	score := 0.0
 
-	// Count include keyword matches
+	// Score include keyword matches
 	for _, keyword := range c.keywords.Include {
-		if c.matchesKeyword(searchText, keyword) {
-			score++
-		}
+		score += c.keywordScore(fields, keyword)
 	}
 
-	// Count category keyword matches
-	for _, keywords := range c.keywords.Categories {
-		for _, keyword := range keywords {
-			if c.matchesKeyword(searchText, keyword) {
-				score++
-			}
+	// Score category keyword matches
+	for _, categoryName := range c.categoryOrder {
+		for _, keyword := range c.keywords.Categories[categoryName] {
+			score += c.keywordScore(fields, keyword)
 		}
 	}
 
+	// Score rule expression matches
+	score += c.rulesScore(fields, c.includeRules)
+	for _, categoryName := range c.categoryOrder {
+		score += c.rulesScore(fields, c.categoryRules[categoryName])
+	}
+
 	return score
 }
 
+// keywordScore returns the weighted score of a keyword, or 0 when it does not match
+func (c *Classifier) keywordScore(fields fieldTexts, keyword string) float64 {
+	fieldWeight := c.keywordFieldWeight(fields, keyword)
+	if fieldWeight == 0 {
+		return 0
+	}
+	return c.keywordWeight(keyword) * fieldWeight
+}
+
+// keywordWeight returns the configured weight of a keyword, defaulting to 1
+func (c *Classifier) keywordWeight(keyword string) float64 {
+	if weight, exists := c.keywords.KeywordWeights[keyword]; exists {
+		return weight
+	}
+	return 1.0
+}
+
+// keywordFieldWeight returns the weight of the strongest field the keyword matches in, or 0
+func (c *Classifier) keywordFieldWeight(fields fieldTexts, keyword string) float64 {
+	best := 0.0
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{fields.topics, c.fieldWeights.Topic},
+		{fields.name, c.fieldWeights.Name},
+		{fields.description, c.fieldWeights.Description},
+		{fields.readme, c.fieldWeights.Readme},
+	} {
+		if field.weight > best && field.text != "" && c.matchesKeyword(field.text, keyword) {
+			best = field.weight
+		}
+	}
+	return best
+}
+
+// fieldTexts holds the lowercase text of each repository field used for matching
+type fieldTexts struct {
+	name        string
+	description string
+	topics      string
+	readme      string
+	all         string
+}
+
+// namedField pairs a field name with its text
+type namedField struct {
+	name string
+	text string
+}
+
+// named returns the individual fields in display order
+func (f fieldTexts) named() []namedField {
+	return []namedField{
+		{"name", f.name},
+		{"description", f.description},
+		{"topics", f.topics},
+		{"readme", f.readme},
+	}
+}
+
+// buildFieldTexts prepares lowercase per-field texts for a repository
+func (c *Classifier) buildFieldTexts(repo models.RepoMetadata) fieldTexts {
+	return fieldTexts{
+		name:        strings.ToLower(repo.Name),
+		description: strings.ToLower(repo.Description),
+		topics:      strings.ToLower(strings.Join(repo.Topics, " ")),
+		readme:      c.buildReadmeText(repo),
+		all:         c.buildSearchText(repo),
+	}
+}
+
 // buildSearchText concatenates repo name, description, and topics into a lowercase search string
 func (c *Classifier) buildSearchText(repo models.RepoMetadata) string {
 	parts := []string{
@@ -180,3 +483,8 @@ func (c *Classifier) buildSearchText(repo models.RepoMetadata) string {
 
 	return strings.ToLower(strings.Join(parts, " "))
 }
+
+// buildReadmeText returns the lowercase README text, kept separate from the main search text
+func (c *Classifier) buildReadmeText(repo models.RepoMetadata) string {
+	return strings.ToLower(repo.Readme)
+}
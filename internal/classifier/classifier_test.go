@@ -82,7 +82,8 @@ func TestClassifier_Classify(t *testing.T) {
 
 func TestClassifier_MatchesInclude(t *testing.T) {
 	keywords := config.KeywordConfig{
-		Include: []string{"ai", "llm", "machine-learning"},
+		Include:            []string{"ai", "llm", "machine-learning"},
+		MatchCompoundParts: true, // names such as "ai-project" match by their parts
 	}
 
 	classifier := New(keywords)
@@ -148,7 +149,8 @@ func TestClassifier_MatchesInclude(t *testing.T) {
 
 func TestClassifier_MatchesExclude(t *testing.T) {
 	keywords := config.KeywordConfig{
-		Exclude: []string{"tutorial", "awesome-list", "learning"},
+		Exclude:            []string{"tutorial", "awesome-list", "learning"},
+		MatchCompoundParts: true, // names such as "ai-tutorial" match by their parts
 	}
 
 	classifier := New(keywords)
@@ -191,6 +193,22 @@ func TestClassifier_MatchesExclude(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "plural of exclude keyword",
+			repo: models.RepoMetadata{
+				Name:        "llm-guides",
+				Description: "Step-by-step tutorials",
+			},
+			expected: true,
+		},
+		{
+			name: "exclude keywords do not match by stem",
+			repo: models.RepoMetadata{
+				Name:        "feedback-agent",
+				Description: "LLM agent that learns from feedback and learned skills",
+			},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -210,6 +228,7 @@ func TestClassifier_AssignCategories(t *testing.T) {
 			"llm":   {"llm", "language-model", "gpt"},
 			"rag":   {"rag", "retrieval", "vector"},
 		},
+		MatchCompoundParts: true,
 	}
 
 	classifier := New(keywords)
@@ -348,6 +367,9 @@ func TestClassifier_CalculateMatchScore(t *testing.T) {
 			"agent": {"agent", "autonomous"},
 			"llm":   {"llm", "gpt"},
 		},
+		MatchCompoundParts: true,
+		// Unit field weights reduce the score to a plain match count
+		FieldWeights: config.FieldWeights{Topic: 1, Name: 1, Description: 1, Readme: 1},
 	}
 
 	classifier := New(keywords)
@@ -355,7 +377,7 @@ func TestClassifier_CalculateMatchScore(t *testing.T) {
 	tests := []struct {
 		name     string
 		repo     models.RepoMetadata
-		expected int
+		expected float64
 	}{
 		{
 			name: "counts all keyword matches",
@@ -387,7 +409,7 @@ func TestClassifier_CalculateMatchScore(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			result := classifier.calculateMatchScore(tt.repo)
 			if result != tt.expected {
-				t.Errorf("expected score %d, got %d", tt.expected, result)
+				t.Errorf("expected score %.2f, got %.2f", tt.expected, result)
 			}
 		})
 	}
@@ -541,3 +563,580 @@ func TestClassifier_IntegrationTest(t *testing.T) {
 		}
 	}
 }
+
+func TestClassifier_ReadmeMatching(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include: []string{"llm"},
+		Exclude: []string{"tutorial"},
+		Categories: map[string][]string{
+			"agent": {"agent"},
+			"rag":   {"rag", "retrieval"},
+		},
+	}
+
+	readmeOnly := models.RepoMetadata{
+		Name:        "sparkle",
+		Description: "✨",
+		Readme:      "an llm agent framework with retrieval and rag support. see the tutorial",
+	}
+
+	t.Run("readme-only match does not count toward include by default", func(t *testing.T) {
+		classifier := New(keywords)
+		if classifier.matchesInclude(readmeOnly) {
+			t.Error("expected README-only match to be ignored for include")
+		}
+	})
+
+	t.Run("readme-only match counts toward include when enabled", func(t *testing.T) {
+		withReadme := keywords
+		withReadme.ReadmeCountsForInclude = true
+		classifier := New(withReadme)
+
+		result := classifier.Classify([]models.RepoMetadata{readmeOnly})
+		if len(result) != 1 {
+			t.Fatalf("expected README-only repo to be included, got %d repos", len(result))
+		}
+		if result[0].PrimaryCategory != "rag" {
+			t.Errorf("expected primary category rag, got %s", result[0].PrimaryCategory)
+		}
+	})
+
+	t.Run("readme does not trigger exclude", func(t *testing.T) {
+		classifier := New(keywords)
+		if classifier.matchesExclude(readmeOnly) {
+			t.Error("expected README text to be ignored for exclude")
+		}
+	})
+
+	t.Run("primary fields outweigh readme for primary category", func(t *testing.T) {
+		classifier := New(keywords)
+		repo := models.RepoMetadata{
+			Name:        "agent",
+			Description: "An LLM project",
+			Readme:      "rag retrieval",
+		}
+		categories := classifier.assignCategories(repo)
+		if len(categories) != 2 {
+			t.Fatalf("expected README to add a category, got %v", categories)
+		}
+		if primary := classifier.selectPrimaryCategory(repo, categories); primary != "agent" {
+			t.Errorf("expected primary category agent, got %s", primary)
+		}
+	})
+
+	t.Run("readme-only matches are down-weighted in match score", func(t *testing.T) {
+		classifier := New(keywords)
+		repo := models.RepoMetadata{
+			Name:        "llm-kit",
+			Description: "An LLM project",
+			Readme:      "agent rag retrieval",
+		}
+		// llm in description = 1, plus agent, rag, retrieval from README at 0.5 each
+		if score := classifier.calculateMatchScore(repo); score != 2.5 {
+			t.Errorf("expected match score 2.5, got %.2f", score)
+		}
+	})
+}
+
+func TestClassifier_WeightedMatchScore(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include: []string{"llm"},
+		Categories: map[string][]string{
+			"agent": {"agent"},
+			"rag":   {"rag"},
+		},
+		KeywordWeights: map[string]float64{"rag": 0.5},
+	}
+
+	tests := []struct {
+		name     string
+		repo     models.RepoMetadata
+		expected float64
+	}{
+		{
+			name:     "topic match uses topic weight",
+			repo:     models.RepoMetadata{Name: "toolkit", Topics: []string{"llm"}},
+			expected: 3,
+		},
+		{
+			name:     "name match uses name weight",
+			repo:     models.RepoMetadata{Name: "llm", Description: "toolkit"},
+			expected: 2,
+		},
+		{
+			name:     "description match uses description weight",
+			repo:     models.RepoMetadata{Name: "toolkit", Description: "an llm toolkit"},
+			expected: 1,
+		},
+		{
+			name:     "strongest field wins when a keyword matches several",
+			repo:     models.RepoMetadata{Name: "llm", Description: "an llm toolkit", Topics: []string{"llm"}},
+			expected: 3,
+		},
+		{
+			name:     "keyword weight scales the field weight",
+			repo:     models.RepoMetadata{Name: "toolkit", Description: "llm", Topics: []string{"rag"}},
+			expected: 2.5, // llm in description (1) + rag in topics (0.5 * 3)
+		},
+	}
+
+	classifier := New(keywords)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if score := classifier.calculateMatchScore(tt.repo); score != tt.expected {
+				t.Errorf("expected score %.2f, got %.2f", tt.expected, score)
+			}
+		})
+	}
+
+	t.Run("topic match outranks description match for primary category", func(t *testing.T) {
+		repo := models.RepoMetadata{
+			Name:        "toolkit",
+			Description: "an llm agent",
+			Topics:      []string{"rag"},
+		}
+		categories := classifier.assignCategories(repo)
+		if primary := classifier.selectPrimaryCategory(repo, categories); primary != "rag" {
+			t.Errorf("expected primary category rag, got %s", primary)
+		}
+	})
+
+	t.Run("min match score drops weak matches", func(t *testing.T) {
+		strict := keywords
+		strict.MinMatchScore = 2
+		repos := []models.RepoMetadata{
+			{Owner: "a", Name: "toolkit", Description: "an llm toolkit"},
+			{Owner: "b", Name: "toolkit", Topics: []string{"llm"}},
+		}
+
+		result := New(strict).Classify(repos)
+		if len(result) != 1 || result[0].Metadata.Owner != "b" {
+			t.Errorf("expected only the topic-matched repo to pass, got %+v", result)
+		}
+	})
+}
+
+func TestClassifier_RuleExpressions(t *testing.T) {
+	keywords := config.KeywordConfig{
+		IncludeRules: []string{`agent AND NOT game`, `rag OR (vector AND database)`},
+		ExcludeRules: []string{`topic:awesome-list`},
+		Categories: map[string][]string{
+			"agent": {},
+			"rag":   {"retrieval"},
+		},
+		CategoryRules: map[string][]string{
+			"agent": {`name:agent OR "tool calling"`},
+			"rag":   {`desc:"vector database"`},
+		},
+	}
+
+	classifier := New(keywords)
+
+	tests := []struct {
+		name             string
+		repo             models.RepoMetadata
+		included         bool
+		expectedCategory string
+	}{
+		{
+			name:             "agent without game is included",
+			repo:             models.RepoMetadata{Name: "helper", Description: "An agent with tool calling"},
+			included:         true,
+			expectedCategory: "agent",
+		},
+		{
+			name:     "agent game is rejected by NOT",
+			repo:     models.RepoMetadata{Name: "helper", Description: "An agent that plays a game"},
+			included: false,
+		},
+		{
+			name:             "grouped AND satisfies OR",
+			repo:             models.RepoMetadata{Name: "store", Description: "A vector database"},
+			included:         true,
+			expectedCategory: "rag",
+		},
+		{
+			name:     "partial group does not match",
+			repo:     models.RepoMetadata{Name: "store", Description: "A vector math library"},
+			included: false,
+		},
+		{
+			name:     "topic qualifier exclude",
+			repo:     models.RepoMetadata{Name: "agents", Description: "Collection", Topics: []string{"awesome-list"}},
+			included: false,
+		},
+		{
+			name:     "topic qualifier ignores description",
+			repo:     models.RepoMetadata{Name: "agent", Description: "Not an awesome-list"},
+			included: true,
+			// name:agent qualifier matches the agent category rule
+			expectedCategory: "agent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := classifier.Classify([]models.RepoMetadata{tt.repo})
+			if (len(result) == 1) != tt.included {
+				t.Fatalf("expected included=%v, got %d results", tt.included, len(result))
+			}
+			if tt.included && result[0].PrimaryCategory != tt.expectedCategory {
+				t.Errorf("expected primary category %s, got %s", tt.expectedCategory, result[0].PrimaryCategory)
+			}
+		})
+	}
+}
+
+func TestMatchesPhrase(t *testing.T) {
+	tests := []struct {
+		text     string
+		phrase   string
+		expected bool
+	}{
+		{"a vector database for llms", "vector database", true},
+		{"a Vector   Database", "vector database", true},
+		{"vector databases", "vector database", false},
+		{"supervector database", "vector database", false},
+		{"(vector database)", "vector database", true},
+		{"a vector-database client", "vector database", true},
+		{"a vector_database client", "vector-database", true},
+	}
+
+	for _, tt := range tests {
+		if got := matchesPhrase(tt.text, tt.phrase); got != tt.expected {
+			t.Errorf("matchesPhrase(%q, %q) = %v, want %v", tt.text, tt.phrase, got, tt.expected)
+		}
+	}
+}
+
+func TestClassifier_Explain(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include:      []string{"llm"},
+		Exclude:      []string{"tutorial"},
+		ExcludeRules: []string{`topic:awesome`},
+		Categories: map[string][]string{
+			"agent": {"agent"},
+			"rag":   {"rag"},
+		},
+		FieldWeights: config.FieldWeights{Topic: 1, Name: 1, Description: 1, Readme: 1},
+	}
+	classifier := New(keywords)
+
+	t.Run("records matched keywords per field and category counts", func(t *testing.T) {
+		trace := classifier.Explain(models.RepoMetadata{
+			Name:        "agent",
+			Description: "llm agent toolkit",
+			Topics:      []string{"rag"},
+		})
+
+		if !trace.Included {
+			t.Fatalf("expected repo to be included, got reason %q", trace.Reason)
+		}
+		if got := strings.Join(trace.MatchedKeywords["description"], ","); got != "llm,agent" {
+			t.Errorf("expected description matches llm,agent, got %s", got)
+		}
+		if got := strings.Join(trace.MatchedKeywords["topics"], ","); got != "rag" {
+			t.Errorf("expected topic matches rag, got %s", got)
+		}
+		if len(trace.Categories) != 2 {
+			t.Fatalf("expected 2 traced categories, got %+v", trace.Categories)
+		}
+		for _, category := range trace.Categories {
+			if category.MatchCount != 1 {
+				t.Errorf("expected 1 match for %s, got %d", category.Name, category.MatchCount)
+			}
+		}
+		if trace.TieBreak == "" {
+			t.Error("expected tie-break to be recorded for equally scored categories")
+		}
+	})
+
+	t.Run("records excluding keyword", func(t *testing.T) {
+		trace := classifier.Explain(models.RepoMetadata{Name: "llm-course", Description: "An LLM tutorial"})
+		if trace.Included {
+			t.Fatal("expected repo to be excluded")
+		}
+		if trace.ExcludedBy != `keyword "tutorial"` {
+			t.Errorf("expected exclusion by tutorial keyword, got %q", trace.ExcludedBy)
+		}
+	})
+
+	t.Run("records excluding rule", func(t *testing.T) {
+		trace := classifier.Explain(models.RepoMetadata{Name: "list", Description: "llm links", Topics: []string{"awesome"}})
+		if trace.ExcludedBy != `rule "topic:awesome"` {
+			t.Errorf("expected exclusion by topic rule, got %q", trace.ExcludedBy)
+		}
+	})
+
+	t.Run("records excluding content type", func(t *testing.T) {
+		byType := New(config.KeywordConfig{
+			Include:             []string{"llm"},
+			ExcludeContentTypes: []string{"list"},
+			FieldWeights:        config.FieldWeights{Topic: 1, Name: 1, Description: 1, Readme: 1},
+		})
+		repo := models.RepoMetadata{Name: "awesome-llm", Description: "A curated list of LLM resources"}
+
+		trace := byType.Explain(repo)
+		if trace.Included {
+			t.Fatal("expected repo to be excluded")
+		}
+		if trace.ExcludedBy != `content type "list"` {
+			t.Errorf("expected exclusion by list content type, got %q", trace.ExcludedBy)
+		}
+		if len(trace.ContentSignals) == 0 {
+			t.Error("expected content signals to be recorded")
+		}
+
+		trace = classifier.Explain(repo)
+		if !trace.Included || trace.ContentType != "list" {
+			t.Errorf("expected included list without content type exclusion, got included=%v type=%q", trace.Included, trace.ContentType)
+		}
+	})
+
+	t.Run("classify stores the trace", func(t *testing.T) {
+		result := classifier.Classify([]models.RepoMetadata{{Name: "kit", Description: "llm agent"}})
+		if len(result) != 1 || result[0].Trace == nil {
+			t.Fatalf("expected classified repo with trace, got %+v", result)
+		}
+		if result[0].Trace.PrimaryCategory != result[0].PrimaryCategory {
+			t.Errorf("trace primary category %q differs from %q", result[0].Trace.PrimaryCategory, result[0].PrimaryCategory)
+		}
+	})
+}
+
+func TestClassifier_CategoryPriority(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include: []string{"llm"},
+		Categories: map[string][]string{
+			"agent": {"agent"},
+			"rag":   {"rag"},
+			"tools": {"cli"},
+		},
+		CategoryPriority: []string{"rag"},
+	}
+	classifier := New(keywords)
+	repo := models.RepoMetadata{Name: "kit", Description: "llm agent with rag and a cli"}
+
+	// Repeat to catch map-order randomness
+	for i := 0; i < 20; i++ {
+		result := classifier.Classify([]models.RepoMetadata{repo})
+		if len(result) != 1 {
+			t.Fatalf("expected 1 classified repo, got %d", len(result))
+		}
+		if got := strings.Join(result[0].Categories, ","); got != "rag,agent,tools" {
+			t.Fatalf("expected categories in priority order rag,agent,tools, got %s", got)
+		}
+		if result[0].PrimaryCategory != "rag" {
+			t.Fatalf("expected tie to go to priority category rag, got %s", result[0].PrimaryCategory)
+		}
+	}
+}
+
+func TestClassifier_Overrides(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include: []string{"llm"},
+		Categories: map[string][]string{
+			"agent": {"agent"},
+			"tools": {"cli"},
+		},
+	}
+	overrides := map[string]config.RepoOverride{
+		"spam/llm-list":  {Exclude: true},
+		"acme/widgets":   {Include: true, Category: "tools"},
+		"acme/agent-kit": {Category: "tools", DisplayName: "Agent Kit", Note: "Editor's pick"},
+		"acme/off-topic": {Pin: true},
+	}
+	classifier := New(keywords).WithOverrides(overrides)
+
+	repos := []models.RepoMetadata{
+		{Owner: "spam", Name: "llm-list", Description: "llm agent links"},
+		{Owner: "acme", Name: "widgets", Description: "ui widgets"},
+		{Owner: "Acme", Name: "Agent-Kit", Description: "llm agent"},
+		{Owner: "acme", Name: "off-topic", Description: "a game"},
+	}
+
+	result := classifier.Classify(repos)
+	byKey := make(map[string]models.ClassifiedRepo)
+	for _, repo := range result {
+		byKey[strings.ToLower(repo.Key())] = repo
+	}
+
+	if _, exists := byKey["spam/llm-list"]; exists {
+		t.Error("expected exclude override to drop spam/llm-list")
+	}
+
+	widgets, exists := byKey["acme/widgets"]
+	if !exists {
+		t.Fatal("expected include override to keep acme/widgets")
+	}
+	if widgets.PrimaryCategory != "tools" || len(widgets.Categories) != 1 {
+		t.Errorf("expected forced category tools, got %s %v", widgets.PrimaryCategory, widgets.Categories)
+	}
+
+	agentKit := byKey["acme/agent-kit"]
+	if agentKit.PrimaryCategory != "tools" {
+		t.Errorf("expected forced primary category tools, got %s", agentKit.PrimaryCategory)
+	}
+	if agentKit.DisplayName != "Agent Kit" || agentKit.CuratorNote != "Editor's pick" {
+		t.Errorf("expected display name and note to be carried, got %+v", agentKit)
+	}
+	if agentKit.Trace == nil || agentKit.Trace.Override != "category=tools" {
+		t.Errorf("expected trace to record the override, got %+v", agentKit.Trace)
+	}
+
+	if offTopic, exists := byKey["acme/off-topic"]; !exists || !offTopic.Pinned {
+		t.Error("expected pinned repo to be included and marked pinned")
+	}
+}
+
+func TestClassifier_CJKKeywords(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include: []string{"智能体", "大模型", "llm"},
+		Exclude: []string{"教程"},
+		Categories: map[string][]string{
+			"agent": {"智能体", "agent"},
+			"rag":   {"检索增强", "rag"},
+		},
+	}
+	classifier := New(keywords)
+
+	tests := []struct {
+		name     string
+		text     string
+		keyword  string
+		expected bool
+	}{
+		{"CJK keyword inside unsegmented text", "基于大模型的智能体框架", "智能体", true},
+		{"CJK keyword absent", "基于大模型的框架", "智能体", false},
+		{"Latin keyword next to CJK", "基于LLM的智能体框架", "llm", true},
+		{"Latin keyword before full-width punctuation", "支持RAG，多模态", "rag", true},
+		{"Latin keyword in full-width brackets", "（agent）框架", "agent", true},
+		{"Japanese keyword", "LLMエージェントフレームワーク", "エージェント", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifier.matchesKeyword(tt.text, tt.keyword); got != tt.expected {
+				t.Errorf("matchesKeyword(%q, %q) = %v, want %v", tt.text, tt.keyword, got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("classifies Chinese descriptions", func(t *testing.T) {
+		repos := []models.RepoMetadata{
+			{Owner: "a", Name: "zhineng", Description: "基于大模型的智能体框架"},
+			{Owner: "b", Name: "jiaocheng", Description: "大模型入门教程"},
+			{Owner: "c", Name: "search", Description: "检索增强生成（RAG）工具"},
+		}
+
+		result := classifier.Classify(repos)
+		if len(result) != 1 {
+			t.Fatalf("expected 1 classified repo, got %d: %+v", len(result), result)
+		}
+		if result[0].Metadata.Owner != "a" || result[0].PrimaryCategory != "agent" {
+			t.Errorf("expected a/zhineng in agent, got %s in %s", result[0].Key(), result[0].PrimaryCategory)
+		}
+	})
+
+	t.Run("CJK phrases in rule expressions", func(t *testing.T) {
+		withRules := keywords
+		withRules.Include = nil
+		withRules.IncludeRules = []string{`desc:"检索增强" AND rag`}
+		result := New(withRules).Classify([]models.RepoMetadata{
+			{Owner: "c", Name: "search", Description: "检索增强生成（RAG）工具"},
+		})
+		if len(result) != 1 {
+			t.Errorf("expected CJK phrase rule to match, got %d repos", len(result))
+		}
+	})
+}
+
+func TestClassifier_SynonymsAndStemming(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include: []string{"rag", "llm", "machine learning", "embedding"},
+		Synonyms: [][]string{
+			{"rag", "retrieval-augmented", "retrieval augmented generation"},
+			{"llm", "large language model"},
+		},
+	}
+	classifier := New(keywords)
+
+	tests := []struct {
+		name     string
+		text     string
+		keyword  string
+		expected bool
+	}{
+		{"synonym with hyphen", "a retrieval-augmented chatbot", "rag", true},
+		{"multi-word synonym", "Retrieval Augmented Generation for docs", "rag", true},
+		{"synonym group is symmetric", "fast rag pipeline", "retrieval augmented generation", true},
+		{"plural multi-word synonym", "serving large language models", "llm", true},
+		{"stemmed plural", "LLMs in production", "llm", true},
+		{"stemmed -ing form", "embeddings and embedded search", "embedding", true},
+		{"space keyword matches hyphenated text", "a machine-learning toolkit", "machine learning", true},
+		{"hyphen keyword matches spaced text", "a machine learning toolkit", "machine-learning", true},
+		{"underscore variant", "machine_learning utils", "machine learning", true},
+		{"words must be consecutive", "machine vision and deep learning", "machine learning", false},
+		{"single word does not match part of a compound", "machine-learning and deep-learning models", "learning", false},
+		{"single word matches a whole word", "learning resources for llms", "learning", true},
+		{"single word prefix of a compound", "an agent-kit for tools", "agent", true},
+		{"short stem is not a prefix", "string utilities", "str", false},
+		{"unrelated word", "a retrieval system", "rag", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifier.matchesKeyword(tt.text, tt.keyword); got != tt.expected {
+				t.Errorf("matchesKeyword(%q, %q) = %v, want %v", tt.text, tt.keyword, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClassifier_CoversCategoryTerm(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include:    []string{"llm"},
+		Exclude:    []string{"tutorial"},
+		Categories: map[string][]string{"vision": {"cv"}},
+	}
+
+	if New(keywords).CoversCategoryTerm("cv-tools") {
+		t.Error("expected a hyphenated term not to match by its parts by default")
+	}
+	keywords.MatchCompoundParts = true
+	classifier := New(keywords)
+	if !classifier.CoversCategoryTerm("cv-tools") {
+		t.Error("expected match_compound_parts to apply to category terms")
+	}
+	if classifier.CoversCategoryTerm("llm") || !classifier.CoversTerm("llm") {
+		t.Error("expected include keywords to cover terms only in CoversTerm")
+	}
+}
+
+func TestClassifier_ShippedConfig(t *testing.T) {
+	cfg, err := config.Load("../../config")
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	classifier := New(cfg.Keywords)
+
+	tests := []struct {
+		repo     models.RepoMetadata
+		included bool
+	}{
+		{models.RepoMetadata{Name: "torch-agents", Description: "Deep learning framework for LLM agents"}, true},
+		{models.RepoMetadata{Name: "ml-kit", Description: "Machine learning toolkit", Topics: []string{"deep-learning"}}, true},
+		{models.RepoMetadata{Name: "feedback-agent", Description: "LLM agent that learns from feedback"}, true},
+		{models.RepoMetadata{Name: "awesome-llm", Description: "A curated list of LLM tools"}, false},
+		{models.RepoMetadata{Name: "llm-course", Description: "LLM course with lessons for beginners"}, false},
+		{models.RepoMetadata{Name: "llm-links", Description: "Learning resources for LLM engineers"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repo.Name, func(t *testing.T) {
+			if trace := classifier.Explain(tt.repo); trace.Included != tt.included {
+				t.Errorf("expected included=%v, got %+v", tt.included, trace)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package classifier
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+// Embedder turns texts into vectors; implemented by embedding.Client
+type Embedder interface {
+	Embed(texts []string) ([][]float64, error)
+}
+
+// EmbeddingClassifier assigns categories by cosine similarity between each
+// repository's name, description and topics and a prototype text per category.
+// Keyword exclusions and curation overrides still apply. In hybrid mode a repository
+// is included when either keywords or similarity match, and categories are combined.
+type EmbeddingClassifier struct {
+	keyword   *Classifier
+	embedder  Embedder
+	threshold float64
+	hybrid    bool
+	logger    zerolog.Logger
+}
+
+// NewEmbeddingClassifier wraps a keyword classifier with semantic category matching
+func NewEmbeddingClassifier(keyword *Classifier, embedder Embedder, hybrid bool, logger zerolog.Logger) *EmbeddingClassifier {
+	threshold := keyword.keywords.SimilarityThreshold
+	if threshold == 0 {
+		threshold = config.DefaultSimilarityThreshold
+	}
+
+	return &EmbeddingClassifier{
+		keyword:   keyword,
+		embedder:  embedder,
+		threshold: threshold,
+		hybrid:    hybrid,
+		logger:    logger,
+	}
+}
+
+// Classify filters and categorizes repositories by semantic similarity.
+// If the embeddings endpoint fails, it falls back to keyword classification.
+func (e *EmbeddingClassifier) Classify(repos []models.RepoMetadata) []models.ClassifiedRepo {
+	traces, err := e.explainAll(repos)
+	if err != nil {
+		e.logger.Warn().Err(err).Msg("semantic classification failed, falling back to keyword rules")
+		return e.keyword.Classify(repos)
+	}
+
+	var classified []models.ClassifiedRepo
+	for i, repo := range repos {
+		if traces[i].Included {
+			classified = append(classified, e.keyword.newClassifiedRepo(repo, traces[i]))
+		}
+	}
+
+	return classified
+}
+
+// Explain returns the classification trace of a single repository, including
+// its similarity to every category prototype
+func (e *EmbeddingClassifier) Explain(repo models.RepoMetadata) (models.ClassificationTrace, error) {
+	traces, err := e.explainAll([]models.RepoMetadata{repo})
+	if err != nil {
+		return models.ClassificationTrace{}, err
+	}
+	return traces[0], nil
+}
+
+// explainAll embeds category prototypes and repositories in one pass and
+// combines the similarities with the keyword trace of each repository
+func (e *EmbeddingClassifier) explainAll(repos []models.RepoMetadata) ([]models.ClassificationTrace, error) {
+	if len(repos) == 0 {
+		return nil, nil
+	}
+
+	categories := e.keyword.categoryOrder
+	texts := make([]string, 0, len(categories)+len(repos))
+	for _, categoryName := range categories {
+		texts = append(texts, e.prototypeText(categoryName))
+	}
+	for _, repo := range repos {
+		texts = append(texts, repoText(repo))
+	}
+
+	vectors, err := e.embedder.Embed(texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(vectors))
+	}
+
+	prototypes := vectors[:len(categories)]
+	traces := make([]models.ClassificationTrace, len(repos))
+	for i, repo := range repos {
+		traces[i] = e.keyword.Explain(repo)
+		e.applySimilarities(&traces[i], repo, prototypes, vectors[len(categories)+i])
+	}
+
+	return traces, nil
+}
+
+// applySimilarities records prototype similarities on a keyword trace and
+// updates inclusion and categories according to the classifier mode
+func (e *EmbeddingClassifier) applySimilarities(trace *models.ClassificationTrace, repo models.RepoMetadata, prototypes [][]float64, vector []float64) {
+	categories := e.keyword.categoryOrder
+	trace.Similarities = make(map[string]float64, len(categories))
+
+	var semantic []models.CategoryTrace
+	best, bestSimilarity := "", 0.0
+	for i, categoryName := range categories {
+		similarity := cosineSimilarity(vector, prototypes[i])
+		trace.Similarities[categoryName] = similarity
+
+		if similarity >= e.threshold {
+			semantic = append(semantic, models.CategoryTrace{Name: categoryName, Similarity: similarity})
+			if best == "" || similarity > bestSimilarity {
+				best, bestSimilarity = categoryName, similarity
+			}
+		}
+	}
+
+	override := e.keyword.overrides[strings.ToLower(repo.Key())]
+	semanticIncluded := len(semantic) > 0 && trace.ExcludedBy == ""
+	semanticReason := fmt.Sprintf("semantic match: %s (similarity %.2f)", best, bestSimilarity)
+
+	// Include, exclude and pin overrides were already applied by the keyword trace
+	overridden := override.Include || override.Exclude || override.Pin
+
+	if e.hybrid {
+		if !overridden && !trace.Included && semanticIncluded {
+			trace.Included = true
+			trace.Reason = semanticReason
+		}
+		for _, category := range semantic {
+			if index := categoryIndex(trace.Categories, category.Name); index >= 0 {
+				trace.Categories[index].Similarity = category.Similarity
+			} else {
+				trace.Categories = append(trace.Categories, category)
+			}
+		}
+		if trace.PrimaryCategory == "" {
+			trace.PrimaryCategory = best
+		}
+		return
+	}
+
+	if !overridden {
+		trace.Included = semanticIncluded
+		switch {
+		case semanticIncluded:
+			trace.Reason = semanticReason
+		case trace.ExcludedBy == "":
+			trace.Reason = fmt.Sprintf("no category prototype reached similarity %.2f", e.threshold)
+		}
+	}
+	trace.Categories = semantic
+	trace.MatchScore = bestSimilarity
+	trace.TieBreak = ""
+	if override.Category == "" {
+		trace.PrimaryCategory = best
+	}
+}
+
+// prototypeText returns the text embedded to represent a category
+func (e *EmbeddingClassifier) prototypeText(categoryName string) string {
+	if prototype := e.keyword.keywords.CategoryPrototypes[categoryName]; prototype != "" {
+		return prototype
+	}
+	return categoryName + ": " + strings.Join(e.keyword.keywords.Categories[categoryName], ", ")
+}
+
+// repoText returns the text embedded to represent a repository
+func repoText(repo models.RepoMetadata) string {
+	parts := []string{repo.Name}
+	if repo.Description != "" {
+		parts = append(parts, repo.Description)
+	}
+	if len(repo.Topics) > 0 {
+		parts = append(parts, "Topics: "+strings.Join(repo.Topics, ", "))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// categoryIndex returns the index of a category in traces, or -1
+func categoryIndex(categories []models.CategoryTrace, name string) int {
+	for i, category := range categories {
+		if category.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// cosineSimilarity returns the cosine of the angle between two vectors,
+// or 0 when they are empty or differ in length
+func cosineSimilarity(a []float64, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
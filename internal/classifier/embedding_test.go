@@ -0,0 +1,173 @@
+package classifier
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+// fakeEmbedder maps texts onto two topic axes (agents, vision) plus a constant
+// axis, so unrelated texts still have a non-zero vector
+type fakeEmbedder struct {
+	err error
+}
+
+func (f fakeEmbedder) Embed(texts []string) ([][]float64, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	axes := [][]string{
+		{"agent", "autonomous", "planner"},
+		{"vision", "image", "photo"},
+	}
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		text = strings.ToLower(text)
+		vector := []float64{0, 0, 0.2}
+		for axis, words := range axes {
+			for _, word := range words {
+				if strings.Contains(text, word) {
+					vector[axis]++
+				}
+			}
+		}
+		vectors[i] = vector
+	}
+	return vectors, nil
+}
+
+func semanticKeywords() config.KeywordConfig {
+	return config.KeywordConfig{
+		Include: []string{"agent"},
+		Exclude: []string{"tutorial"},
+		Categories: map[string][]string{
+			"agent":  {"agent"},
+			"vision": {"vision"},
+		},
+		CategoryPrototypes: map[string]string{
+			"agent":  "autonomous agent",
+			"vision": "image vision",
+		},
+		SimilarityThreshold: 0.6,
+	}
+}
+
+func classifyByKey(classifier RepoClassifier, repos []models.RepoMetadata) map[string]models.ClassifiedRepo {
+	byKey := make(map[string]models.ClassifiedRepo)
+	for _, repo := range classifier.Classify(repos) {
+		byKey[repo.Key()] = repo
+	}
+	return byKey
+}
+
+func TestEmbeddingClassifier_Classify(t *testing.T) {
+	repos := []models.RepoMetadata{
+		{Owner: "a", Name: "planner", Description: "task planner that works autonomously"},
+		{Owner: "a", Name: "photo-tool", Description: "photo editor"},
+		{Owner: "a", Name: "agent-tutorial", Description: "agent tutorial"},
+		{Owner: "a", Name: "game", Description: "a puzzle game"},
+		{Owner: "a", Name: "agent-eyes", Description: "agent with image understanding"},
+	}
+
+	t.Run("embedding mode", func(t *testing.T) {
+		classifier := NewEmbeddingClassifier(New(semanticKeywords()), fakeEmbedder{}, false, zerolog.Nop())
+		byKey := classifyByKey(classifier, repos)
+
+		if repo, exists := byKey["a/planner"]; !exists || repo.PrimaryCategory != "agent" {
+			t.Errorf("expected a/planner in agent without keyword matches, got %+v", repo)
+		}
+		if repo, exists := byKey["a/photo-tool"]; !exists || repo.PrimaryCategory != "vision" {
+			t.Errorf("expected a/photo-tool in vision, got %+v", repo)
+		}
+		if _, exists := byKey["a/agent-tutorial"]; exists {
+			t.Error("expected exclude keywords to still apply")
+		}
+		if _, exists := byKey["a/game"]; exists {
+			t.Error("expected unrelated repo to stay below the similarity threshold")
+		}
+
+		repo := byKey["a/agent-eyes"]
+		if len(repo.Categories) != 2 {
+			t.Errorf("expected both categories above threshold, got %v", repo.Categories)
+		}
+		if repo.Trace == nil || len(repo.Trace.Similarities) != 2 {
+			t.Errorf("expected similarities in trace, got %+v", repo.Trace)
+		}
+	})
+
+	t.Run("hybrid mode", func(t *testing.T) {
+		classifier := NewEmbeddingClassifier(New(semanticKeywords()), fakeEmbedder{}, true, zerolog.Nop())
+		byKey := classifyByKey(classifier, repos)
+
+		if _, exists := byKey["a/planner"]; !exists {
+			t.Error("expected semantic match to include a/planner")
+		}
+		if _, exists := byKey["a/agent-tutorial"]; exists {
+			t.Error("expected exclude keywords to still apply")
+		}
+
+		repo := byKey["a/agent-eyes"]
+		if repo.PrimaryCategory != "agent" {
+			t.Errorf("expected keyword primary category agent to win, got %s", repo.PrimaryCategory)
+		}
+		if len(repo.Categories) != 2 || repo.Categories[1] != "vision" {
+			t.Errorf("expected keyword and semantic categories combined, got %v", repo.Categories)
+		}
+	})
+
+	t.Run("overrides still apply", func(t *testing.T) {
+		keywordClassifier := New(semanticKeywords()).WithOverrides(map[string]config.RepoOverride{
+			"a/photo-tool": {Exclude: true},
+			"a/game":       {Include: true, Category: "vision"},
+		})
+		classifier := NewEmbeddingClassifier(keywordClassifier, fakeEmbedder{}, false, zerolog.Nop())
+		byKey := classifyByKey(classifier, repos)
+
+		if _, exists := byKey["a/photo-tool"]; exists {
+			t.Error("expected exclude override to drop a/photo-tool")
+		}
+		if repo, exists := byKey["a/game"]; !exists || repo.PrimaryCategory != "vision" {
+			t.Errorf("expected include override with forced category, got %+v", repo)
+		}
+	})
+
+	t.Run("falls back to keywords on error", func(t *testing.T) {
+		classifier := NewEmbeddingClassifier(New(semanticKeywords()), fakeEmbedder{err: errors.New("unavailable")}, false, zerolog.Nop())
+		byKey := classifyByKey(classifier, repos)
+
+		if _, exists := byKey["a/planner"]; exists {
+			t.Error("expected keyword fallback to skip a/planner")
+		}
+		if _, exists := byKey["a/agent-eyes"]; !exists {
+			t.Error("expected keyword fallback to include a/agent-eyes")
+		}
+	})
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []float64
+		b        []float64
+		expected float64
+	}{
+		{"identical", []float64{1, 2}, []float64{1, 2}, 1},
+		{"orthogonal", []float64{1, 0}, []float64{0, 1}, 0},
+		{"length mismatch", []float64{1, 0}, []float64{1}, 0},
+		{"zero vector", []float64{0, 0}, []float64{1, 1}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := cosineSimilarity(tt.a, tt.b); result < tt.expected-1e-9 || result > tt.expected+1e-9 {
+				t.Errorf("cosineSimilarity(%v, %v) = %f, want %f", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
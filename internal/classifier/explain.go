@@ -0,0 +1,153 @@
+package classifier
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/contenttype"
+	"ai-repo-insights/internal/models"
+	"ai-repo-insights/internal/rules"
+)
+
+// Explain classifies a single repository and records why it was included or
+// rejected, which keywords matched in which field and how the primary category
+// was chosen. Categories are traced even for rejected repositories.
+func (c *Classifier) Explain(repo models.RepoMetadata) models.ClassificationTrace {
+	fields := c.buildFieldTexts(repo)
+
+	trace := models.ClassificationTrace{
+		MatchedKeywords: c.matchedKeywords(fields),
+		MatchedRules:    c.matchingRules(fields, c.includeRules),
+		ExcludedBy:      c.excludedBy(fields),
+		MatchScore:      c.calculateMatchScore(repo),
+		MinMatchScore:   c.keywords.MinMatchScore,
+	}
+
+	detection := contenttype.Detect(repo)
+	trace.ContentType = detection.Type
+	trace.ContentSignals = detection.Signals
+	if trace.ExcludedBy == "" && c.excludesContentType(detection.Type) {
+		trace.ExcludedBy = fmt.Sprintf("content type %q", detection.Type)
+	}
+
+	categories := c.assignCategories(repo)
+	for _, categoryName := range categories {
+		trace.Categories = append(trace.Categories, c.traceCategory(fields, categoryName))
+	}
+	trace.PrimaryCategory, trace.TieBreak = c.pickPrimaryCategory(fields, categories)
+
+	switch {
+	case !c.matchesInclude(repo):
+		trace.Reason = "no include keyword or rule matched"
+	case trace.ExcludedBy != "":
+		trace.Reason = "excluded by " + trace.ExcludedBy
+	case trace.MatchScore < c.keywords.MinMatchScore:
+		trace.Reason = fmt.Sprintf("match score %.2f is below min_match_score %.2f", trace.MatchScore, c.keywords.MinMatchScore)
+	default:
+		trace.Included = true
+		trace.Reason = "matched include filter"
+	}
+
+	if override, exists := c.overrides[strings.ToLower(repo.Key())]; exists {
+		c.applyOverride(&trace, override)
+	}
+
+	return trace
+}
+
+// excludesContentType reports whether the configuration excludes a content type
+func (c *Classifier) excludesContentType(contentType string) bool {
+	for _, excluded := range c.keywords.ExcludeContentTypes {
+		if excluded == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// matchedKeywords lists the include and category keywords found in each field
+func (c *Classifier) matchedKeywords(fields fieldTexts) map[string][]string {
+	keywords := append([]string{}, c.keywords.Include...)
+
+	for _, categoryName := range c.categoryOrder {
+		keywords = append(keywords, c.keywords.Categories[categoryName]...)
+	}
+
+	matched := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, keyword := range keywords {
+		if seen[keyword] {
+			continue
+		}
+		seen[keyword] = true
+
+		for _, field := range fields.named() {
+			if field.text != "" && c.matchesKeyword(field.text, keyword) {
+				matched[field.name] = append(matched[field.name], keyword)
+			}
+		}
+	}
+
+	return matched
+}
+
+// traceCategory records which keywords and rules of a category matched
+func (c *Classifier) traceCategory(fields fieldTexts, categoryName string) models.CategoryTrace {
+	trace := models.CategoryTrace{
+		Name:  categoryName,
+		Rules: c.matchingRules(fields, c.categoryRules[categoryName]),
+		Score: c.categoryScore(fields, categoryName),
+	}
+
+	for _, keyword := range c.keywords.Categories[categoryName] {
+		if c.keywordFieldWeight(fields, keyword) > 0 {
+			trace.Keywords = append(trace.Keywords, keyword)
+		}
+	}
+	trace.MatchCount = len(trace.Keywords) + len(trace.Rules)
+
+	return trace
+}
+
+// matchingRules returns the canonical form of each rule that matches
+func (c *Classifier) matchingRules(fields fieldTexts, exprs []rules.Expr) []string {
+	var matched []string
+	matcher := &repoMatcher{classifier: c, fields: fields}
+	for _, expr := range exprs {
+		if expr.Eval(matcher) {
+			matched = append(matched, expr.String())
+		}
+	}
+	return matched
+}
+
+// applyOverride applies a curation override on top of the keyword-based outcome
+func (c *Classifier) applyOverride(trace *models.ClassificationTrace, override config.RepoOverride) {
+	var applied []string
+
+	switch {
+	case override.Exclude:
+		trace.Included = false
+		trace.Reason = "excluded by override"
+		applied = append(applied, "exclude")
+	case override.Include || override.Pin:
+		if !trace.Included {
+			trace.Reason = "included by override (" + trace.Reason + ")"
+		}
+		trace.Included = true
+		if override.Pin {
+			applied = append(applied, "pin")
+		} else {
+			applied = append(applied, "include")
+		}
+	}
+
+	if override.Category != "" && override.Category != trace.PrimaryCategory {
+		trace.PrimaryCategory = override.Category
+		trace.TieBreak = ""
+		applied = append(applied, "category="+override.Category)
+	}
+
+	trace.Override = strings.Join(applied, ", ")
+}
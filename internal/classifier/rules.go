@@ -0,0 +1,167 @@
+package classifier
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"ai-repo-insights/internal/rules"
+	"ai-repo-insights/internal/textutil"
+)
+
+// compileRules parses rule expressions, skipping any that fail to parse
+func compileRules(expressions []string) []rules.Expr {
+	compiled := make([]rules.Expr, 0, len(expressions))
+	for _, expression := range expressions {
+		expr, err := rules.Parse(expression)
+		if err != nil {
+			continue
+		}
+		compiled = append(compiled, expr)
+	}
+	return compiled
+}
+
+// matchesAnyRule checks if any compiled rule matches the repository fields
+func (c *Classifier) matchesAnyRule(fields fieldTexts, exprs []rules.Expr) bool {
+	matcher := &repoMatcher{classifier: c, fields: fields}
+	for _, expr := range exprs {
+		if expr.Eval(matcher) {
+			return true
+		}
+	}
+	return false
+}
+
+// rulesScore sums the scores of matching rules. A matching rule scores the weight
+// of the strongest field among its positively matched terms, or 1 when none apply
+// (e.g. a rule that only excludes).
+func (c *Classifier) rulesScore(fields fieldTexts, exprs []rules.Expr) float64 {
+	matcher := &repoMatcher{classifier: c, fields: fields}
+	score := 0.0
+	for _, expr := range exprs {
+		if !expr.Eval(matcher) {
+			continue
+		}
+
+		best := 0.0
+		for _, term := range expr.Terms() {
+			if weight := c.termFieldWeight(fields, term); weight > best {
+				best = weight
+			}
+		}
+		if best == 0 {
+			best = 1.0
+		}
+		score += best
+	}
+	return score
+}
+
+// termFieldWeight returns the weight of the strongest field a rule term matches in, or 0
+func (c *Classifier) termFieldWeight(fields fieldTexts, term rules.Term) float64 {
+	candidates := []struct {
+		field  rules.Field
+		text   string
+		weight float64
+	}{
+		{rules.FieldTopic, fields.topics, c.fieldWeights.Topic},
+		{rules.FieldName, fields.name, c.fieldWeights.Name},
+		{rules.FieldDescription, fields.description, c.fieldWeights.Description},
+		{rules.FieldReadme, fields.readme, c.fieldWeights.Readme},
+	}
+
+	best := 0.0
+	for _, candidate := range candidates {
+		if term.Field != rules.FieldAny && term.Field != candidate.field {
+			continue
+		}
+		// Unqualified terms never match the README (see repoMatcher)
+		if term.Field == rules.FieldAny && candidate.field == rules.FieldReadme {
+			continue
+		}
+		if candidate.weight > best && matchesTerm(c, candidate.text, term) {
+			best = candidate.weight
+		}
+	}
+	return best
+}
+
+// repoMatcher evaluates rule terms against the fields of a single repository
+type repoMatcher struct {
+	classifier *Classifier
+	fields     fieldTexts
+}
+
+// MatchTerm implements rules.Matcher
+func (m *repoMatcher) MatchTerm(term rules.Term) bool {
+	var text string
+	switch term.Field {
+	case rules.FieldName:
+		text = m.fields.name
+	case rules.FieldDescription:
+		text = m.fields.description
+	case rules.FieldTopic:
+		text = m.fields.topics
+	case rules.FieldReadme:
+		text = m.fields.readme
+	default:
+		text = m.fields.all
+	}
+
+	return matchesTerm(m.classifier, text, term)
+}
+
+// matchesTerm checks a single rule term against text
+func matchesTerm(c *Classifier, text string, term rules.Term) bool {
+	if text == "" {
+		return false
+	}
+	if term.Phrase {
+		return matchesPhrase(text, term.Text)
+	}
+	return c.matchesKeyword(text, term.Text)
+}
+
+// matchesPhrase checks if the exact phrase occurs in text on word boundaries.
+// Phrases containing CJK characters match as plain substrings.
+func matchesPhrase(text string, phrase string) bool {
+	text = strings.Join(strings.Fields(unifySeparators(strings.ToLower(text))), " ")
+	phrase = strings.Join(strings.Fields(unifySeparators(phrase)), " ")
+	if textutil.ContainsCJK(phrase) {
+		return strings.Contains(text, phrase)
+	}
+
+	for offset := 0; offset <= len(text); {
+		idx := strings.Index(text[offset:], phrase)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(phrase)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		offset = start + 1
+	}
+
+	return false
+}
+
+// unifySeparators treats hyphens and underscores as spaces, so "machine-learning"
+// matches the phrase "machine learning" and vice versa
+func unifySeparators(text string) string {
+	return strings.NewReplacer("-", " ", "_", " ").Replace(text)
+}
+
+// isWordRune reports whether r continues a word; CJK characters act as boundaries
+// for Latin phrases, since CJK text does not separate words with spaces
+func isWordRune(r rune) bool {
+	if r == utf8.RuneError || textutil.IsCJK(r) {
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
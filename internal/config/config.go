@@ -1,13 +1,17 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"ai-repo-insights/internal/contenttype"
 	apperrors "ai-repo-insights/internal/errors"
+	"ai-repo-insights/internal/rules"
 )
 
 // KeywordConfig represents keyword filtering configuration
@@ -15,6 +19,162 @@ type KeywordConfig struct {
 	Include    []string            `json:"include"`
 	Exclude    []string            `json:"exclude"`
 	Categories map[string][]string `json:"categories"`
+
+	// Synonyms groups interchangeable terms, e.g. ["rag", "retrieval-augmented generation"].
+	// A keyword in a group also matches every other term in the group.
+	Synonyms [][]string `json:"synonyms"`
+
+	// CategoryPriority orders categories for assignment and primary-category
+	// tie-breaks. Unlisted categories follow in keywords.json order.
+	CategoryPriority []string `json:"category_priority"`
+
+	// Boolean rule expressions, e.g. "agent AND NOT game" or "rag OR (vector AND database)".
+	// A repository matches a list when any keyword or any rule in it matches.
+	IncludeRules  []string            `json:"include_rules"`
+	ExcludeRules  []string            `json:"exclude_rules"`
+	CategoryRules map[string][]string `json:"category_rules"`
+
+	// ExcludeContentTypes drops repositories detected as these content types
+	// (list, tutorial, dataset, paper-code, template, fork)
+	ExcludeContentTypes []string `json:"exclude_content_types"`
+
+	// ReadmeCountsForInclude lets README-only keyword matches satisfy the include filter
+	ReadmeCountsForInclude bool `json:"readme_counts_for_include"`
+
+	// MatchCompoundParts lets single-word keywords match the parts of hyphenated and
+	// underscored words ("ai" matches "ai-project"); by default they match whole words
+	MatchCompoundParts bool `json:"match_compound_parts"`
+
+	// Weighted scoring: a keyword match scores its keyword weight (default 1)
+	// multiplied by the weight of the strongest field it matched in
+	KeywordWeights map[string]float64 `json:"keyword_weights"`
+	FieldWeights   FieldWeights       `json:"field_weights"`
+	MinMatchScore  float64            `json:"min_match_score"`
+
+	// Semantic classification (settings.classifier_mode "embedding" or "hybrid"):
+	// each category is described by a prototype text, defaulting to its name and keywords,
+	// and a repository joins a category when their cosine similarity reaches the threshold
+	CategoryPrototypes  map[string]string `json:"category_prototypes"`
+	SimilarityThreshold float64           `json:"similarity_threshold"`
+
+	// categoryOrder is the order in which categories appear in keywords.json
+	categoryOrder []string
+}
+
+// UnmarshalJSON decodes keyword configuration and records the file order of categories
+func (k *KeywordConfig) UnmarshalJSON(data []byte) error {
+	type plainKeywordConfig KeywordConfig
+	// Decode over the current values, so defaults set before decoding survive for absent fields
+	decoded := plainKeywordConfig(*k)
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Categories json.RawMessage `json:"categories"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	order, err := objectKeys(raw.Categories)
+	if err != nil {
+		return err
+	}
+
+	*k = KeywordConfig(decoded)
+	k.categoryOrder = order
+	return nil
+}
+
+// OrderedCategories returns every category name in priority order: category_priority
+// first, then keywords.json order, then alphabetically for categories set in code
+func (k KeywordConfig) OrderedCategories() []string {
+	ordered := make([]string, 0, len(k.Categories))
+	seen := make(map[string]bool, len(k.Categories))
+	add := func(names []string) {
+		for _, name := range names {
+			if _, exists := k.Categories[name]; exists && !seen[name] {
+				seen[name] = true
+				ordered = append(ordered, name)
+			}
+		}
+	}
+
+	add(k.CategoryPriority)
+	add(k.categoryOrder)
+
+	var remaining []string
+	for name := range k.Categories {
+		if !seen[name] {
+			remaining = append(remaining, name)
+		}
+	}
+	sort.Strings(remaining)
+	add(remaining)
+
+	return ordered
+}
+
+// objectKeys returns the keys of a JSON object in document order
+func objectKeys(data json.RawMessage) ([]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, nil
+	}
+
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		keys = append(keys, key)
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// FieldWeights holds per-field match multipliers; a weight of 0 disables matching in
+// that field. Fields left out of keywords.json take their defaults, and setting every
+// weight to 0 is the same as leaving them all out.
+type FieldWeights struct {
+	Topic       float64 `json:"topic"`
+	Name        float64 `json:"name"`
+	Description float64 `json:"description"`
+	Readme      float64 `json:"readme"`
+}
+
+// DefaultFieldWeights returns the default field multipliers (topic > name > description > README)
+func DefaultFieldWeights() FieldWeights {
+	return FieldWeights{
+		Topic:       3.0,
+		Name:        2.0,
+		Description: 1.0,
+		Readme:      0.5,
+	}
+}
+
+// WithDefaults returns the default weights when none are set (the zero value), as for
+// keyword configuration built in code, and w otherwise
+func (w FieldWeights) WithDefaults() FieldWeights {
+	if w == (FieldWeights{}) {
+		return DefaultFieldWeights()
+	}
+	return w
 }
 
 // Settings represents operational settings
@@ -28,8 +188,117 @@ type Settings struct {
 	ReportLanguage          string `json:"report_language"`
 	ReportIDFormat          string `json:"report_id_format"`
 	FilterDomain            string `json:"filter_domain"`
+	FetchReadme             bool   `json:"fetch_readme"`
+	ReadmeMaxChars          int    `json:"readme_max_chars"`
+	ClassifierMode          string `json:"classifier_mode"`
+
+	// LLMCategorize asks the LLM to categorize repositories that match no category
+	LLMCategorize            bool    `json:"llm_categorize"`
+	LLMCategoryMinConfidence float64 `json:"llm_category_min_confidence"`
+
+	// Emerging theme discovery clusters classified repositories by description similarity
+	EmergingThemeMinSize    int     `json:"emerging_theme_min_size"`
+	EmergingThemeSimilarity float64 `json:"emerging_theme_similarity"`
+
+	// ImputeMissingWindows estimates star windows a repository is missing from
+	// using saved trending snapshots
+	ImputeMissingWindows bool `json:"impute_missing_windows"`
+
+	// Diversity constraints on the top N selection; 0 disables each
+	MaxPerCategory int `json:"max_per_category"`
+	MaxPerOwner    int `json:"max_per_owner"`
+	MinPerCategory int `json:"min_per_category"`
+	MinPerLanguage int `json:"min_per_language"`
+
+	// Forecast projects next-week stars from saved snapshots of the last ForecastLookbackDays
+	Forecast             bool `json:"forecast"`
+	ForecastLookbackDays int  `json:"forecast_lookback_days"`
+
+	// ScoreWeights weigh the star rates of each trending window in the score
+	ScoreWeights ScoreWeights `json:"score_weights"`
+
+	// Leaderboards are additional rankings of all scored repositories shown after the top N
+	Leaderboards []LeaderboardConfig `json:"leaderboards"`
+
+	// AnomalyScreening flags repositories whose stars look manipulated
+	AnomalyScreening AnomalyScreening `json:"anomaly_screening"`
+}
+
+// AnomalyScreening configures the star-manipulation checks
+type AnomalyScreening struct {
+	// Action is what happens to flagged repositories: off, annotate or exclude
+	Action string `json:"action"`
+	// StargazerSample is the number of latest stargazers sampled per repository (at most 100)
+	StargazerSample int `json:"stargazer_sample"`
+	// MaxBurstRatio bounds the busiest sampled hour against the hourly trending pace
+	MaxBurstRatio float64 `json:"max_burst_ratio"`
+	// MaxNewAccountShare bounds the share of sampled stargazers with new or empty accounts
+	MaxNewAccountShare float64 `json:"max_new_account_share"`
+	NewAccountDays     int     `json:"new_account_days"`
+	// MaxStarsPerFork and MaxStarsPerWatcher bound total stars against forks and watchers
+	// for repositories with at least RatioMinStars stars
+	MaxStarsPerFork    float64 `json:"max_stars_per_fork"`
+	MaxStarsPerWatcher float64 `json:"max_stars_per_watcher"`
+	RatioMinStars      int     `json:"ratio_min_stars"`
+}
+
+// Anomaly screening actions
+const (
+	AnomalyActionOff      = "off"
+	AnomalyActionAnnotate = "annotate"
+	AnomalyActionExclude  = "exclude"
+)
+
+// ScoreWeights weigh stars today, the weekly daily average and the monthly daily average
+type ScoreWeights struct {
+	Today float64 `json:"today"`
+	Week  float64 `json:"week"`
+	Month float64 `json:"month"`
+}
+
+// DefaultScoreWeights emphasize recent activity while considering sustained trends
+var DefaultScoreWeights = ScoreWeights{Today: 0.6, Week: 0.3, Month: 0.1}
+
+// LeaderboardConfig describes one additional ranking
+type LeaderboardConfig struct {
+	// Metric is the value repositories are ranked by
+	Metric string `json:"metric"`
+	Title  string `json:"title"`
+	TopK   int    `json:"top_k"`
+}
+
+// Leaderboard metrics
+const (
+	LeaderboardStarsToday     = "stars_today"
+	LeaderboardHeat7          = "heat_7"
+	LeaderboardScore          = "score"
+	LeaderboardRelativeGrowth = "relative_growth"
+)
+
+// leaderboardTitles holds the default title of each leaderboard metric
+var leaderboardTitles = map[string]string{
+	LeaderboardStarsToday:     "Hottest Today",
+	LeaderboardHeat7:          "Top Weekly",
+	LeaderboardScore:          "Top Score",
+	LeaderboardRelativeGrowth: "Biggest Relative Growth",
 }
 
+// OtherCategory is the summary bucket for repositories that match no configured category
+const OtherCategory = "Other"
+
+// DefaultSimilarityThreshold is the default cosine similarity needed for a semantic category match
+const DefaultSimilarityThreshold = 0.4
+
+// DefaultLLMCategoryMinConfidence is the default confidence needed to accept an LLM category
+const DefaultLLMCategoryMinConfidence = 0.5
+
+// Classifier modes
+const (
+	ClassifierModeKeyword   = "keyword"
+	ClassifierModeEmbedding = "embedding"
+	ClassifierModeHybrid    = "hybrid"
+)
+
 // LLMConfig represents LLM integration settings
 type LLMConfig struct {
 	BaseURL         string  `json:"base_url"`
@@ -40,6 +309,10 @@ type LLMConfig struct {
 	RoleDescription string  `json:"role_description"`
 	OutputTone      string  `json:"output_tone"`
 	Temperature     float64 `json:"temperature"`
+
+	// OpenAI-compatible /embeddings endpoint used by the semantic classifier
+	EmbeddingBaseURL string `json:"embedding_base_url"`
+	EmbeddingModel   string `json:"embedding_model"`
 }
 
 // Config represents the complete system configuration
@@ -48,6 +321,10 @@ type Config struct {
 	Keywords  KeywordConfig `json:"keywords"`
 	Settings  Settings      `json:"settings"`
 	LLM       LLMConfig     `json:"llm"`
+
+	// Overrides holds optional per-repository curation rules from overrides.json,
+	// keyed by lowercase owner/repo
+	Overrides map[string]RepoOverride `json:"overrides"`
 }
 
 // Load loads all configuration files from the specified directory
@@ -63,12 +340,16 @@ func Load(configDir string) (*Config, error) {
 
 	// Load keywords
 	keywordsPath := filepath.Join(configDir, "keywords.json")
+	// Defaults for which 0 is a valid setting are set before decoding, so only absent fields take them
+	config.Keywords.FieldWeights = DefaultFieldWeights()
 	if err := loadJSONFile(keywordsPath, &config.Keywords); err != nil {
 		return nil, apperrors.NewConfigError("failed to load keywords.json", err)
 	}
+	applyKeywordDefaults(&config.Keywords)
 
 	// Load settings
 	settingsPath := filepath.Join(configDir, "settings.json")
+	config.Settings.LLMCategoryMinConfidence = DefaultLLMCategoryMinConfidence
 	if err := loadJSONFile(settingsPath, &config.Settings); err != nil {
 		return nil, apperrors.NewConfigError("failed to load settings.json", err)
 	}
@@ -81,6 +362,13 @@ func Load(configDir string) (*Config, error) {
 	}
 	applyLLMDefaults(&config.LLM)
 
+	// Load optional curation overrides
+	overrides, err := loadOverrides(filepath.Join(configDir, "overrides.json"))
+	if err != nil {
+		return nil, apperrors.NewConfigError("failed to load overrides.json", err)
+	}
+	config.Overrides = overrides
+
 	return config, nil
 }
 
@@ -108,12 +396,85 @@ func (c *Config) Validate() []string {
 	}
 
 	// Validate keywords
-	if len(c.Keywords.Include) == 0 {
+	if len(c.Keywords.Include) == 0 && len(c.Keywords.IncludeRules) == 0 {
 		errors = append(errors, "include keywords cannot be empty")
 	}
 	if len(c.Keywords.Categories) == 0 {
 		errors = append(errors, "categories cannot be empty")
 	}
+	for category := range c.Keywords.Categories {
+		if strings.EqualFold(category, OtherCategory) {
+			errors = append(errors, fmt.Sprintf("category name %q is reserved for uncategorized repositories", category))
+		}
+	}
+	errors = append(errors, validateRules("include_rules", c.Keywords.IncludeRules)...)
+	errors = append(errors, validateRules("exclude_rules", c.Keywords.ExcludeRules)...)
+	ruleCategories := make([]string, 0, len(c.Keywords.CategoryRules))
+	for category := range c.Keywords.CategoryRules {
+		ruleCategories = append(ruleCategories, category)
+	}
+	sort.Strings(ruleCategories)
+	for _, category := range ruleCategories {
+		categoryRules := c.Keywords.CategoryRules[category]
+		if _, exists := c.Keywords.Categories[category]; !exists {
+			errors = append(errors, fmt.Sprintf("category_rules references unknown category %q", category))
+		}
+		errors = append(errors, validateRules("category_rules."+category, categoryRules)...)
+	}
+	for _, contentType := range c.Keywords.ExcludeContentTypes {
+		if !contenttype.IsType(contentType) || contentType == contenttype.Project {
+			errors = append(errors, fmt.Sprintf("exclude_content_types has unknown content type %q (expected one of %s)",
+				contentType, strings.Join(contenttype.Types[:len(contenttype.Types)-1], ", ")))
+		}
+	}
+	for i, group := range c.Keywords.Synonyms {
+		if len(group) < 2 {
+			errors = append(errors, fmt.Sprintf("synonyms[%d] must list at least two terms", i))
+		}
+		for _, term := range group {
+			if strings.TrimSpace(term) == "" {
+				errors = append(errors, fmt.Sprintf("synonyms[%d] contains an empty term", i))
+				break
+			}
+		}
+	}
+	prioritized := make(map[string]bool, len(c.Keywords.CategoryPriority))
+	for _, category := range c.Keywords.CategoryPriority {
+		if _, exists := c.Keywords.Categories[category]; !exists {
+			errors = append(errors, fmt.Sprintf("category_priority references unknown category %q", category))
+		}
+		if prioritized[category] {
+			errors = append(errors, fmt.Sprintf("category_priority lists %q more than once", category))
+		}
+		prioritized[category] = true
+	}
+	if c.Keywords.FieldWeights.Topic < 0 || c.Keywords.FieldWeights.Name < 0 ||
+		c.Keywords.FieldWeights.Description < 0 || c.Keywords.FieldWeights.Readme < 0 {
+		errors = append(errors, "field_weights cannot be negative")
+	}
+	weightedKeywords := make([]string, 0, len(c.Keywords.KeywordWeights))
+	for keyword := range c.Keywords.KeywordWeights {
+		weightedKeywords = append(weightedKeywords, keyword)
+	}
+	sort.Strings(weightedKeywords)
+	for _, keyword := range weightedKeywords {
+		if c.Keywords.KeywordWeights[keyword] < 0 {
+			errors = append(errors, fmt.Sprintf("keyword_weights[%q] cannot be negative", keyword))
+		}
+	}
+	if c.Keywords.MinMatchScore < 0 {
+		errors = append(errors, "min_match_score cannot be negative")
+	}
+	if c.Keywords.SimilarityThreshold < 0 || c.Keywords.SimilarityThreshold > 1 {
+		errors = append(errors, "similarity_threshold must be between 0 and 1")
+	}
+	for _, category := range sortedKeys(c.Keywords.CategoryPrototypes) {
+		if _, exists := c.Keywords.Categories[category]; !exists {
+			errors = append(errors, fmt.Sprintf("category_prototypes references unknown category %q", category))
+		}
+	}
+
+	errors = append(errors, c.validateOverrides()...)
 
 	// Validate settings - required fields
 	if c.Settings.WindowDays <= 0 {
@@ -144,6 +505,55 @@ func (c *Config) Validate() []string {
 	if c.Settings.CacheTTLHours < 0 {
 		errors = append(errors, "cache_ttl_hours cannot be negative")
 	}
+	if c.Settings.ReadmeMaxChars < 0 {
+		errors = append(errors, "readme_max_chars cannot be negative")
+	}
+	if c.Settings.EmergingThemeMinSize < 0 {
+		errors = append(errors, "emerging_theme_min_size cannot be negative")
+	}
+	if c.Settings.EmergingThemeSimilarity < 0 || c.Settings.EmergingThemeSimilarity > 1 {
+		errors = append(errors, "emerging_theme_similarity must be between 0 and 1")
+	}
+	if c.Settings.MaxPerCategory < 0 || c.Settings.MaxPerOwner < 0 ||
+		c.Settings.MinPerCategory < 0 || c.Settings.MinPerLanguage < 0 {
+		errors = append(errors, "max_per_category, max_per_owner, min_per_category and min_per_language cannot be negative")
+	}
+	if c.Settings.MaxPerCategory > 0 && c.Settings.MinPerCategory > c.Settings.MaxPerCategory {
+		errors = append(errors, "min_per_category cannot exceed max_per_category")
+	}
+	if c.Settings.LLMCategoryMinConfidence < 0 || c.Settings.LLMCategoryMinConfidence > 1 {
+		errors = append(errors, "llm_category_min_confidence must be between 0 and 1")
+	}
+	if c.Settings.ForecastLookbackDays < 0 {
+		errors = append(errors, "forecast_lookback_days cannot be negative")
+	}
+	if w := c.Settings.ScoreWeights; w.Today < 0 || w.Week < 0 || w.Month < 0 {
+		errors = append(errors, "score_weights cannot be negative")
+	}
+	errors = append(errors, c.Settings.AnomalyScreening.validate()...)
+	seenLeaderboards := make(map[string]bool)
+	for i, board := range c.Settings.Leaderboards {
+		if _, known := leaderboardTitles[board.Metric]; !known {
+			errors = append(errors, fmt.Sprintf("leaderboards[%d] metric must be %q, %q, %q or %q", i,
+				LeaderboardStarsToday, LeaderboardHeat7, LeaderboardScore, LeaderboardRelativeGrowth))
+		} else if seenLeaderboards[board.Metric] {
+			errors = append(errors, fmt.Sprintf("leaderboards[%d] repeats metric %q", i, board.Metric))
+		}
+		seenLeaderboards[board.Metric] = true
+		if board.TopK < 0 {
+			errors = append(errors, fmt.Sprintf("leaderboards[%d] top_k cannot be negative", i))
+		}
+	}
+	switch c.Settings.ClassifierMode {
+	case "", ClassifierModeKeyword:
+	case ClassifierModeEmbedding, ClassifierModeHybrid:
+		if c.LLM.EmbeddingModel == "" {
+			errors = append(errors, "llm embedding_model is required when classifier_mode is "+c.Settings.ClassifierMode)
+		}
+	default:
+		errors = append(errors, fmt.Sprintf("classifier_mode must be %q, %q or %q",
+			ClassifierModeKeyword, ClassifierModeEmbedding, ClassifierModeHybrid))
+	}
 
 	// Validate LLM config - all fields are required
 	if c.LLM.BaseURL == "" {
@@ -171,6 +581,36 @@ func (c *Config) Validate() []string {
 	return errors
 }
 
+// validateRules parses each rule expression and reports syntax errors with their positions
+func validateRules(field string, expressions []string) []string {
+	var errors []string
+
+	for i, expression := range expressions {
+		if _, err := rules.Parse(expression); err != nil {
+			errors = append(errors, fmt.Sprintf("%s[%d] %q: %s", field, i, expression, err))
+		}
+	}
+
+	return errors
+}
+
+// applyKeywordDefaults applies default values for optional keyword fields
+func applyKeywordDefaults(k *KeywordConfig) {
+	if k.SimilarityThreshold == 0 {
+		k.SimilarityThreshold = DefaultSimilarityThreshold
+	}
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // applySettingsDefaults applies default values for optional settings fields
 func applySettingsDefaults(s *Settings) {
 	// Optional fields with defaults
@@ -186,6 +626,85 @@ func applySettingsDefaults(s *Settings) {
 	if s.ReportIDFormat == "" {
 		s.ReportIDFormat = "YYYY-MM-weekN" // Default format
 	}
+	if s.ReadmeMaxChars == 0 {
+		s.ReadmeMaxChars = 2000 // Default: 2000 characters
+	}
+	if s.ClassifierMode == "" {
+		s.ClassifierMode = ClassifierModeKeyword // Default: keyword rules only
+	}
+	if s.EmergingThemeMinSize == 0 {
+		s.EmergingThemeMinSize = 3 // Default: 3 repositories
+	}
+	if s.EmergingThemeSimilarity == 0 {
+		s.EmergingThemeSimilarity = 0.25 // Default: 0.25 average cosine similarity
+	}
+	if s.ForecastLookbackDays == 0 {
+		s.ForecastLookbackDays = 28 // Default: 4 weeks
+	}
+	if s.ScoreWeights == (ScoreWeights{}) {
+		s.ScoreWeights = DefaultScoreWeights // Default: 0.6 / 0.3 / 0.1
+	}
+	applyAnomalyDefaults(&s.AnomalyScreening)
+	for i := range s.Leaderboards {
+		if s.Leaderboards[i].TopK == 0 {
+			s.Leaderboards[i].TopK = 10 // Default: 10 repositories
+		}
+		if s.Leaderboards[i].Title == "" {
+			s.Leaderboards[i].Title = leaderboardTitles[s.Leaderboards[i].Metric] // Default: per-metric title
+		}
+	}
+}
+
+// applyAnomalyDefaults applies default values for optional anomaly screening fields
+func applyAnomalyDefaults(a *AnomalyScreening) {
+	if a.Action == "" {
+		a.Action = AnomalyActionOff // Default: screening disabled
+	}
+	if a.StargazerSample == 0 {
+		a.StargazerSample = 100 // Default: 100 stargazers
+	}
+	if a.MaxBurstRatio == 0 {
+		a.MaxBurstRatio = 10 // Default: 10× the hourly trending pace
+	}
+	if a.MaxNewAccountShare == 0 {
+		a.MaxNewAccountShare = 0.5 // Default: half of the sample
+	}
+	if a.NewAccountDays == 0 {
+		a.NewAccountDays = 30 // Default: 30 days
+	}
+	if a.MaxStarsPerFork == 0 {
+		a.MaxStarsPerFork = 75 // Default: 75 stars per fork
+	}
+	if a.MaxStarsPerWatcher == 0 {
+		a.MaxStarsPerWatcher = 500 // Default: 500 stars per watcher
+	}
+	if a.RatioMinStars == 0 {
+		a.RatioMinStars = 1000 // Default: 1000 stars
+	}
+}
+
+// validate checks the anomaly screening action and thresholds
+func (a AnomalyScreening) validate() []string {
+	var errors []string
+
+	switch a.Action {
+	case "", AnomalyActionOff, AnomalyActionAnnotate, AnomalyActionExclude:
+	default:
+		errors = append(errors, fmt.Sprintf("anomaly_screening action must be %q, %q or %q",
+			AnomalyActionOff, AnomalyActionAnnotate, AnomalyActionExclude))
+	}
+	if a.StargazerSample < 0 || a.StargazerSample > 100 {
+		errors = append(errors, "anomaly_screening stargazer_sample must be between 0 and 100")
+	}
+	if a.MaxNewAccountShare < 0 || a.MaxNewAccountShare > 1 {
+		errors = append(errors, "anomaly_screening max_new_account_share must be between 0 and 1")
+	}
+	if a.MaxBurstRatio < 0 || a.NewAccountDays < 0 || a.MaxStarsPerFork < 0 ||
+		a.MaxStarsPerWatcher < 0 || a.RatioMinStars < 0 {
+		errors = append(errors, "anomaly_screening thresholds cannot be negative")
+	}
+
+	return errors
 }
 
 // applyLLMDefaults applies default values for optional LLM config fields
@@ -206,6 +725,9 @@ func applyLLMDefaults(l *LLMConfig) {
 	if l.Provider == "" {
 		l.Provider = detectProvider(l.BaseURL) // Auto-detect from base_url
 	}
+	if l.EmbeddingBaseURL == "" {
+		l.EmbeddingBaseURL = l.BaseURL // Default: same endpoint as chat completions
+	}
 }
 
 // detectProvider auto-detects the LLM provider from the base URL
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -116,6 +118,18 @@ func TestDefaultValues(t *testing.T) {
 	if config.Settings.ReportIDFormat != "YYYY-MM-weekN" {
 		t.Errorf("Expected ReportIDFormat default of 'YYYY-MM-weekN', got %s", config.Settings.ReportIDFormat)
 	}
+	if config.Settings.FetchReadme {
+		t.Error("Expected FetchReadme to default to false")
+	}
+	if config.Settings.ReadmeMaxChars != 2000 {
+		t.Errorf("Expected ReadmeMaxChars default of 2000, got %d", config.Settings.ReadmeMaxChars)
+	}
+	if config.Keywords.FieldWeights != DefaultFieldWeights() {
+		t.Errorf("Expected default field weights, got %+v", config.Keywords.FieldWeights)
+	}
+	if config.Settings.LLMCategoryMinConfidence != DefaultLLMCategoryMinConfidence {
+		t.Errorf("Expected LLMCategoryMinConfidence default of %v, got %v", DefaultLLMCategoryMinConfidence, config.Settings.LLMCategoryMinConfidence)
+	}
 
 	if config.LLM.TimeoutSeconds != 60 {
 		t.Errorf("Expected TimeoutSeconds default of 60, got %d", config.LLM.TimeoutSeconds)
@@ -131,6 +145,48 @@ func TestDefaultValues(t *testing.T) {
 	}
 }
 
+// TestExplicitZeroSettings tests that an explicit 0 is kept where 0 is a valid setting
+func TestExplicitZeroSettings(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"languages.json": `["python"]`,
+		"keywords.json": `{
+			"include": ["test"],
+			"categories": {"test": ["test"]},
+			"field_weights": {"readme": 0}
+		}`,
+		"settings.json": `{
+			"window_days": 90,
+			"short_window_days": 30,
+			"top_n": 10,
+			"report_language": "en",
+			"filter_domain": "Test",
+			"llm_category_min_confidence": 0
+		}`,
+		"llm.json": `{"base_url": "https://api.test.com", "model": "test-model", "role_description": "test role"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	config, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	expected := DefaultFieldWeights()
+	expected.Readme = 0
+	if config.Keywords.FieldWeights != expected {
+		t.Errorf("Expected README weight 0 with other defaults, got %+v", config.Keywords.FieldWeights)
+	}
+	if config.Settings.LLMCategoryMinConfidence != 0 {
+		t.Errorf("Expected LLMCategoryMinConfidence 0, got %v", config.Settings.LLMCategoryMinConfidence)
+	}
+}
+
 // TestValidation tests configuration validation
 func TestValidation(t *testing.T) {
 	tests := []struct {
@@ -438,6 +494,257 @@ func TestValidation(t *testing.T) {
 			expectErrors:  true,
 			errorContains: "max_retries",
 		},
+		{
+			name: "invalid rule expression",
+			config: Config{
+				Languages: []string{"python"},
+				Keywords: KeywordConfig{
+					Include:      []string{"test"},
+					IncludeRules: []string{"agent AND (rag OR"},
+					Categories:   map[string][]string{"test": {"test"}},
+				},
+				Settings: Settings{
+					WindowDays:      90,
+					ShortWindowDays: 30,
+					TopN:            10,
+					ReportLanguage:  "en",
+					FilterDomain:    "Test",
+				},
+				LLM: LLMConfig{
+					BaseURL:         "https://api.test.com",
+					Model:           "test",
+					TimeoutSeconds:  60,
+					RoleDescription: "test",
+					OutputTone:      "test",
+					Temperature:     0.7,
+				},
+			},
+			expectErrors:  true,
+			errorContains: "include_rules[0] \"agent AND (rag OR\": position 18",
+		},
+		{
+			name: "category rule for unknown category",
+			config: Config{
+				Languages: []string{"python"},
+				Keywords: KeywordConfig{
+					Include:       []string{"test"},
+					Categories:    map[string][]string{"test": {"test"}},
+					CategoryRules: map[string][]string{"missing": {"agent"}},
+				},
+				Settings: Settings{
+					WindowDays:      90,
+					ShortWindowDays: 30,
+					TopN:            10,
+					ReportLanguage:  "en",
+					FilterDomain:    "Test",
+				},
+				LLM: LLMConfig{
+					BaseURL:         "https://api.test.com",
+					Model:           "test",
+					TimeoutSeconds:  60,
+					RoleDescription: "test",
+					OutputTone:      "test",
+					Temperature:     0.7,
+				},
+			},
+			expectErrors:  true,
+			errorContains: "unknown category",
+		},
+		{
+			name: "include rules satisfy include requirement",
+			config: Config{
+				Languages: []string{"python"},
+				Keywords: KeywordConfig{
+					IncludeRules: []string{"agent AND NOT game"},
+					Categories:   map[string][]string{"test": {"test"}},
+				},
+				Settings: Settings{
+					WindowDays:      90,
+					ShortWindowDays: 30,
+					TopN:            10,
+					ReportLanguage:  "en",
+					FilterDomain:    "Test",
+				},
+				LLM: LLMConfig{
+					BaseURL:         "https://api.test.com",
+					Model:           "test",
+					TimeoutSeconds:  60,
+					RoleDescription: "test",
+					OutputTone:      "test",
+					Temperature:     0.7,
+				},
+			},
+			expectErrors: false,
+		},
+		{
+			name: "reserved other category",
+			config: Config{
+				Languages: []string{"python"},
+				Keywords: KeywordConfig{
+					Include:    []string{"test"},
+					Categories: map[string][]string{"test": {"test"}, "other": {"misc"}},
+				},
+				Settings: Settings{
+					WindowDays:      90,
+					ShortWindowDays: 30,
+					TopN:            10,
+					ReportLanguage:  "en",
+					FilterDomain:    "Test",
+				},
+				LLM: LLMConfig{
+					BaseURL:         "https://api.test.com",
+					Model:           "test",
+					TimeoutSeconds:  60,
+					RoleDescription: "test",
+					OutputTone:      "test",
+					Temperature:     0.7,
+				},
+			},
+			expectErrors:  true,
+			errorContains: "reserved",
+		},
+		{
+			name: "llm category confidence out of range",
+			config: Config{
+				Languages: []string{"python"},
+				Keywords: KeywordConfig{
+					Include:    []string{"test"},
+					Categories: map[string][]string{"test": {"test"}},
+				},
+				Settings: Settings{
+					WindowDays:               90,
+					ShortWindowDays:          30,
+					TopN:                     10,
+					ReportLanguage:           "en",
+					FilterDomain:             "Test",
+					LLMCategorize:            true,
+					LLMCategoryMinConfidence: 1.5,
+				},
+				LLM: LLMConfig{
+					BaseURL:         "https://api.test.com",
+					Model:           "test",
+					TimeoutSeconds:  60,
+					RoleDescription: "test",
+					OutputTone:      "test",
+					Temperature:     0.7,
+				},
+			},
+			expectErrors:  true,
+			errorContains: "llm_category_min_confidence",
+		},
+		{
+			name: "unknown leaderboard metric",
+			config: Config{
+				Languages: []string{"python"},
+				Keywords: KeywordConfig{
+					Include:    []string{"test"},
+					Categories: map[string][]string{"test": {"test"}},
+				},
+				Settings: Settings{
+					WindowDays:      90,
+					ShortWindowDays: 30,
+					TopN:            10,
+					ReportLanguage:  "en",
+					FilterDomain:    "Test",
+					Leaderboards:    []LeaderboardConfig{{Metric: "heat_7"}, {Metric: "forks"}},
+				},
+				LLM: LLMConfig{
+					BaseURL:         "https://api.test.com",
+					Model:           "test",
+					TimeoutSeconds:  60,
+					RoleDescription: "test",
+					OutputTone:      "test",
+					Temperature:     0.7,
+				},
+			},
+			expectErrors:  true,
+			errorContains: "leaderboards[1] metric",
+		},
+		{
+			name: "unknown anomaly screening action",
+			config: Config{
+				Languages: []string{"python"},
+				Keywords: KeywordConfig{
+					Include:    []string{"test"},
+					Categories: map[string][]string{"test": {"test"}},
+				},
+				Settings: Settings{
+					WindowDays:       90,
+					ShortWindowDays:  30,
+					TopN:             10,
+					ReportLanguage:   "en",
+					FilterDomain:     "Test",
+					AnomalyScreening: AnomalyScreening{Action: "hide"},
+				},
+				LLM: LLMConfig{
+					BaseURL:         "https://api.test.com",
+					Model:           "test",
+					TimeoutSeconds:  60,
+					RoleDescription: "test",
+					OutputTone:      "test",
+					Temperature:     0.7,
+				},
+			},
+			expectErrors:  true,
+			errorContains: "anomaly_screening action",
+		},
+		{
+			name: "min per category above max",
+			config: Config{
+				Languages: []string{"python"},
+				Keywords: KeywordConfig{
+					Include:    []string{"test"},
+					Categories: map[string][]string{"test": {"test"}},
+				},
+				Settings: Settings{
+					WindowDays:      90,
+					ShortWindowDays: 30,
+					TopN:            10,
+					ReportLanguage:  "en",
+					FilterDomain:    "Test",
+					MaxPerCategory:  2,
+					MinPerCategory:  3,
+				},
+				LLM: LLMConfig{
+					BaseURL:         "https://api.test.com",
+					Model:           "test",
+					TimeoutSeconds:  60,
+					RoleDescription: "test",
+					OutputTone:      "test",
+					Temperature:     0.7,
+				},
+			},
+			expectErrors:  true,
+			errorContains: "min_per_category cannot exceed max_per_category",
+		},
+		{
+			name: "unknown excluded content type",
+			config: Config{
+				Languages: []string{"python"},
+				Keywords: KeywordConfig{
+					Include:             []string{"test"},
+					Categories:          map[string][]string{"test": {"test"}},
+					ExcludeContentTypes: []string{"list", "project"},
+				},
+				Settings: Settings{
+					WindowDays:      90,
+					ShortWindowDays: 30,
+					TopN:            10,
+					ReportLanguage:  "en",
+					FilterDomain:    "Test",
+				},
+				LLM: LLMConfig{
+					BaseURL:         "https://api.test.com",
+					Model:           "test",
+					TimeoutSeconds:  60,
+					RoleDescription: "test",
+					OutputTone:      "test",
+					Temperature:     0.7,
+				},
+			},
+			expectErrors:  true,
+			errorContains: `unknown content type "project"`,
+		},
 		{
 			name: "multiple validation errors",
 			config: Config{
@@ -497,6 +804,116 @@ func TestValidation(t *testing.T) {
 	}
 }
 
+func TestOrderedCategories(t *testing.T) {
+	var keywords KeywordConfig
+	data := `{
+		"include": ["ai"],
+		"categories": {"rag": ["rag"], "agent": ["agent"], "llm": ["llm"], "infra": ["gpu"]},
+		"category_priority": ["llm"]
+	}`
+	if err := json.Unmarshal([]byte(data), &keywords); err != nil {
+		t.Fatalf("Failed to parse keywords: %v", err)
+	}
+
+	expected := "llm,rag,agent,infra"
+	if got := strings.Join(keywords.OrderedCategories(), ","); got != expected {
+		t.Errorf("Expected priority then file order %s, got %s", expected, got)
+	}
+
+	// Categories set in code have no file order and fall back to alphabetical
+	keywords = KeywordConfig{
+		Categories:       map[string][]string{"rag": nil, "agent": nil, "llm": nil},
+		CategoryPriority: []string{"rag", "unknown"},
+	}
+	expected = "rag,agent,llm"
+	if got := strings.Join(keywords.OrderedCategories(), ","); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	errors := (&Config{Keywords: keywords}).Validate()
+	found := false
+	for _, err := range errors {
+		if contains(err, `category_priority references unknown category "unknown"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected unknown category_priority entry to be reported, got: %v", errors)
+	}
+}
+
+func TestNonASCIIKeywords(t *testing.T) {
+	var keywords KeywordConfig
+	data := `{
+		"include": ["智能体", "大模型", "エージェント"],
+		"include_rules": ["智能体 AND NOT 教程", "desc:\"检索增强\""],
+		"categories": {"智能体": ["智能体", "agent"]},
+		"keyword_weights": {"大模型": 2}
+	}`
+	if err := json.Unmarshal([]byte(data), &keywords); err != nil {
+		t.Fatalf("Failed to parse keywords: %v", err)
+	}
+
+	if keywords.Include[0] != "智能体" || keywords.KeywordWeights["大模型"] != 2 {
+		t.Errorf("Non-ASCII keywords not preserved: %+v", keywords)
+	}
+	if got := keywords.OrderedCategories(); len(got) != 1 || got[0] != "智能体" {
+		t.Errorf("Expected non-ASCII category name, got %v", got)
+	}
+	if errors := validateRules("include_rules", keywords.IncludeRules); len(errors) > 0 {
+		t.Errorf("Expected non-ASCII rules to parse, got: %v", errors)
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "overrides.json")
+
+	overrides, err := loadOverrides(path)
+	if err != nil || overrides != nil {
+		t.Fatalf("Expected missing overrides.json to be ignored, got %v, %v", overrides, err)
+	}
+
+	data := `{
+		"Acme/Agent-Kit": {"pin": true, "category": "agent", "display_name": "Agent Kit", "note": "Editor's pick"},
+		"spam/awesome-ai": {"exclude": true}
+	}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write overrides.json: %v", err)
+	}
+
+	overrides, err = loadOverrides(path)
+	if err != nil {
+		t.Fatalf("Failed to load overrides: %v", err)
+	}
+
+	override, exists := overrides["acme/agent-kit"]
+	if !exists || !override.Pin || override.DisplayName != "Agent Kit" {
+		t.Errorf("Expected Acme/Agent-Kit to be keyed as acme/agent-kit, got %+v (exists=%v)", override, exists)
+	}
+
+	cfg := &Config{Overrides: overrides}
+
+	cfg.Keywords.Categories = map[string][]string{"llm": {"llm"}}
+	cfg.Overrides["bad-key"] = RepoOverride{Include: true, Exclude: true}
+	errors := cfg.validateOverrides()
+	for _, expected := range []string{
+		`overrides["acme/agent-kit"] references unknown category "agent"`,
+		`overrides key "bad-key" must be in owner/repo form`,
+		`overrides["bad-key"] cannot both exclude and include or pin`,
+	} {
+		found := false
+		for _, err := range errors {
+			if err == expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected error %q, got: %v", expected, errors)
+		}
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s string, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && containsHelper(s, substr))
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// RepoOverride is a curator's manual correction for a single repository
+type RepoOverride struct {
+	Include     bool   `json:"include"`      // Always include, bypassing keyword filters
+	Exclude     bool   `json:"exclude"`      // Always exclude (spam, lists that slip through)
+	Pin         bool   `json:"pin"`          // Always include and keep in the top N
+	Category    string `json:"category"`     // Forced primary category
+	DisplayName string `json:"display_name"` // Name shown in the report instead of the repo name
+	Note        string `json:"note"`         // Curator note rendered in the report
+}
+
+// loadOverrides loads the optional overrides.json, keyed by lowercase owner/repo
+func loadOverrides(path string) (map[string]RepoOverride, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var raw map[string]RepoOverride
+	if err := loadJSONFile(path, &raw); err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]RepoOverride, len(raw))
+	for key, override := range raw {
+		overrides[strings.ToLower(key)] = override
+	}
+
+	return overrides, nil
+}
+
+// validateOverrides checks that override keys and categories are well-formed
+func (c *Config) validateOverrides() []string {
+	var errors []string
+
+	keys := make([]string, 0, len(c.Overrides))
+	for key := range c.Overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		override := c.Overrides[key]
+		if parts := strings.Split(key, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			errors = append(errors, fmt.Sprintf("overrides key %q must be in owner/repo form", key))
+		}
+		if override.Exclude && (override.Include || override.Pin) {
+			errors = append(errors, fmt.Sprintf("overrides[%q] cannot both exclude and include or pin", key))
+		}
+		if override.Category != "" {
+			if _, exists := c.Keywords.Categories[override.Category]; !exists {
+				errors = append(errors, fmt.Sprintf("overrides[%q] references unknown category %q", key, override.Category))
+			}
+		}
+	}
+
+	return errors
+}
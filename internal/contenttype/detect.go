@@ -0,0 +1,144 @@
+package contenttype
+
+import (
+	"sort"
+	"strings"
+
+	"ai-repo-insights/internal/models"
+	"ai-repo-insights/internal/textutil"
+)
+
+// Content types
+const (
+	Project   = "project"
+	List      = "list"
+	Tutorial  = "tutorial"
+	Dataset   = "dataset"
+	PaperCode = "paper-code"
+	Template  = "template"
+	Fork      = "fork"
+)
+
+// Types lists every content type; earlier types win ties
+var Types = []string{Fork, List, Tutorial, Dataset, PaperCode, Template, Project}
+
+// minScore is the signal weight a type needs before it replaces Project
+const minScore = 2
+
+// Signal weights: topics and distinctive phrases are strong, single words are weak.
+// Weak signals only add to a strong one; on their own they never change the type.
+const (
+	strong = 2
+	weak   = 1
+)
+
+// pattern is one piece of evidence for a content type
+type pattern struct {
+	contentType string
+	phrase      string
+	weight      int
+}
+
+// topicPatterns match whole repository topics
+var topicPatterns = []pattern{
+	{List, "awesome", strong}, {List, "awesome-list", strong}, {List, "curated-list", strong},
+	{List, "resources", weak}, {List, "list", weak},
+	{Tutorial, "tutorial", strong}, {Tutorial, "tutorials", strong}, {Tutorial, "course", strong},
+	{Tutorial, "book", strong}, {Tutorial, "learning-resources", strong}, {Tutorial, "education", strong},
+	{Tutorial, "roadmap", strong}, {Tutorial, "interview-questions", strong}, {Tutorial, "examples", weak},
+	{Dataset, "dataset", strong}, {Dataset, "datasets", strong}, {Dataset, "corpus", strong},
+	{Dataset, "benchmark", weak},
+	{PaperCode, "paper", strong}, {PaperCode, "paper-implementation", strong}, {PaperCode, "arxiv", strong},
+	{PaperCode, "neurips", weak}, {PaperCode, "iclr", weak}, {PaperCode, "cvpr", weak}, {PaperCode, "acl", weak},
+	{Template, "template", strong}, {Template, "boilerplate", strong}, {Template, "starter", strong},
+	{Template, "starter-kit", strong}, {Template, "scaffold", strong},
+}
+
+// textPatterns match word sequences in the name and description
+var textPatterns = []pattern{
+	{List, "awesome", strong}, {List, "curated list", strong}, {List, "a list of", strong},
+	{List, "collection of awesome", strong}, {List, "list of resources", strong},
+	{List, "collection of", weak}, {List, "resources", weak}, {List, "papers", weak},
+	{Tutorial, "tutorial", strong}, {Tutorial, "course", strong}, {Tutorial, "lessons", strong},
+	{Tutorial, "step by step", strong}, {Tutorial, "for beginners", strong}, {Tutorial, "from scratch", weak},
+	{Tutorial, "handbook", strong}, {Tutorial, "book", weak}, {Tutorial, "guide", weak},
+	{Tutorial, "learn", weak}, {Tutorial, "roadmap", strong}, {Tutorial, "interview", weak},
+	{Tutorial, "cookbook", strong}, {Tutorial, "notebooks", weak}, {Tutorial, "examples", weak},
+	{Dataset, "dataset", strong}, {Dataset, "data set", strong}, {Dataset, "corpus", strong},
+	{Dataset, "benchmark", weak},
+	{PaperCode, "official implementation", strong}, {PaperCode, "official pytorch implementation", strong},
+	{PaperCode, "code for the paper", strong}, {PaperCode, "code for our paper", strong},
+	{PaperCode, "implementation of the paper", strong}, {PaperCode, "arxiv", strong},
+	{PaperCode, "paper", weak}, {PaperCode, "neurips", weak}, {PaperCode, "iclr", weak},
+	{PaperCode, "icml", weak}, {PaperCode, "cvpr", weak}, {PaperCode, "emnlp", weak},
+	{Template, "template", strong}, {Template, "boilerplate", strong}, {Template, "starter kit", strong},
+	{Template, "starter template", strong}, {Template, "scaffold", strong}, {Template, "starter", weak},
+}
+
+// Detection is the content type of a repository and the signals behind it
+type Detection struct {
+	Type    string   `json:"type"`
+	Signals []string `json:"signals,omitempty"`
+}
+
+// Detect labels a repository by content type. Forks are recognized from API
+// metadata; other types add up signal weights from topics, the repository name and
+// description, and the highest-scoring type with at least minScore and one strong
+// signal wins.
+// Everything else is a Project.
+func Detect(repo models.RepoMetadata) Detection {
+	if repo.IsFork {
+		return Detection{Type: Fork, Signals: []string{"metadata: is_fork"}}
+	}
+
+	scores := make(map[string]int)
+	signals := make(map[string][]string)
+	hasStrong := make(map[string]bool)
+
+	topics := make(map[string]bool, len(repo.Topics))
+	for _, topic := range repo.Topics {
+		topics[strings.ToLower(topic)] = true
+	}
+	for _, p := range topicPatterns {
+		if topics[p.phrase] {
+			scores[p.contentType] += p.weight
+			hasStrong[p.contentType] = hasStrong[p.contentType] || p.weight == strong
+			signals[p.contentType] = append(signals[p.contentType], "topic: "+p.phrase)
+		}
+	}
+
+	// Pad with spaces so phrases only match whole words
+	text := " " + strings.Join(textutil.Words(repo.Name+" "+repo.Description), " ") + " "
+	text = strings.ToLower(text)
+	for _, p := range textPatterns {
+		if strings.Contains(text, " "+p.phrase+" ") {
+			scores[p.contentType] += p.weight
+			hasStrong[p.contentType] = hasStrong[p.contentType] || p.weight == strong
+			signals[p.contentType] = append(signals[p.contentType], "text: "+p.phrase)
+		}
+	}
+
+	best, bestScore := Project, minScore-1
+	for _, contentType := range Types {
+		if hasStrong[contentType] && scores[contentType] > bestScore {
+			best, bestScore = contentType, scores[contentType]
+		}
+	}
+	if best == Project {
+		return Detection{Type: Project}
+	}
+
+	detected := signals[best]
+	sort.Strings(detected)
+	return Detection{Type: best, Signals: detected}
+}
+
+// IsType reports whether name is a known content type
+func IsType(name string) bool {
+	for _, contentType := range Types {
+		if contentType == name {
+			return true
+		}
+	}
+	return false
+}
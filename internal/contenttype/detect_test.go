@@ -0,0 +1,102 @@
+package contenttype
+
+import (
+	"testing"
+
+	"ai-repo-insights/internal/models"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		repo     models.RepoMetadata
+		expected string
+	}{
+		{
+			name:     "curated list",
+			repo:     models.RepoMetadata{Name: "awesome-llm", Description: "A curated list of LLM papers and tools"},
+			expected: List,
+		},
+		{
+			name:     "list by topic",
+			repo:     models.RepoMetadata{Name: "llm-links", Topics: []string{"awesome-list"}},
+			expected: List,
+		},
+		{
+			name:     "framework",
+			repo:     models.RepoMetadata{Name: "pytorch", Description: "Tensors and dynamic neural networks in Python", Topics: []string{"deep-learning"}},
+			expected: Project,
+		},
+		{
+			name:     "course",
+			repo:     models.RepoMetadata{Name: "generative-ai-for-beginners", Description: "21 lessons to get started building with generative AI"},
+			expected: Tutorial,
+		},
+		{
+			name:     "fork",
+			repo:     models.RepoMetadata{Name: "awesome-llm", Description: "A curated list", IsFork: true},
+			expected: Fork,
+		},
+		{
+			name:     "paper code",
+			repo:     models.RepoMetadata{Name: "fast-diffusion", Description: "Official implementation of our ICLR 2025 paper"},
+			expected: PaperCode,
+		},
+		{
+			name:     "template",
+			repo:     models.RepoMetadata{Name: "nextjs-ai-chatbot", Description: "A starter template for building chat apps", Topics: []string{"boilerplate"}},
+			expected: Template,
+		},
+		{
+			name:     "dataset",
+			repo:     models.RepoMetadata{Name: "instruct-data", Description: "Instruction tuning data", Topics: []string{"dataset"}},
+			expected: Dataset,
+		},
+		{
+			name:     "single weak signal",
+			repo:     models.RepoMetadata{Name: "agent-kit", Description: "Build agents, with examples"},
+			expected: Project,
+		},
+		{
+			name:     "weak signals only",
+			repo:     models.RepoMetadata{Name: "agent-sdk", Description: "SDK guide with examples"},
+			expected: Project,
+		},
+		{
+			name:     "learning framework with notebooks",
+			repo:     models.RepoMetadata{Name: "learn-rl", Description: "Reinforcement learning library with examples and notebooks"},
+			expected: Project,
+		},
+		{
+			name:     "recommender mentioning books",
+			repo:     models.RepoMetadata{Name: "shelf", Description: "Book recommendations that learn your taste"},
+			expected: Project,
+		},
+		{
+			name:     "weak signals add to a strong one",
+			repo:     models.RepoMetadata{Name: "llm-guide", Description: "Learn LLMs with notebooks", Topics: []string{"tutorial"}},
+			expected: Tutorial,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(tt.repo)
+			if got.Type != tt.expected {
+				t.Errorf("Detect() = %q (signals %v), want %q", got.Type, got.Signals, tt.expected)
+			}
+			if got.Type != Project && len(got.Signals) == 0 {
+				t.Error("expected signals for a non-project type")
+			}
+		})
+	}
+}
+
+func TestIsType(t *testing.T) {
+	if !IsType(List) || !IsType(Project) {
+		t.Error("expected known content types")
+	}
+	if IsType("awesome") {
+		t.Error("expected unknown content type to be rejected")
+	}
+}
@@ -0,0 +1,211 @@
+package embedding
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/errors"
+)
+
+const (
+	defaultCacheDir = "data/cache/embeddings"
+	maxBatchSize    = 64
+	retryDelay      = 2 * time.Second
+)
+
+// Client embeds texts through an OpenAI-compatible /embeddings endpoint and caches
+// the vectors on disk, keyed by model and text
+type Client struct {
+	baseURL    string
+	model      string
+	apiKey     string
+	maxRetries int
+	retryDelay time.Duration
+	batchSize  int
+	cacheDir   string
+	httpClient *http.Client
+	logger     zerolog.Logger
+}
+
+// New creates an embeddings client from the LLM configuration
+func New(cfg config.LLMConfig, apiKey string, logger zerolog.Logger) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(cfg.EmbeddingBaseURL, "/"),
+		model:      cfg.EmbeddingModel,
+		apiKey:     apiKey,
+		maxRetries: cfg.MaxRetries,
+		retryDelay: retryDelay,
+		batchSize:  maxBatchSize,
+		cacheDir:   defaultCacheDir,
+		httpClient: &http.Client{
+			Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// Embed returns one vector per input text, in order. Cached vectors are reused and
+// only the remaining texts are requested, in batches.
+func (c *Client) Embed(texts []string) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+
+	var missing []int
+	for i, text := range texts {
+		if vector, ok := c.readCache(text); ok {
+			vectors[i] = vector
+			continue
+		}
+		missing = append(missing, i)
+	}
+
+	c.logger.Debug().
+		Int("texts", len(texts)).
+		Int("cached", len(texts)-len(missing)).
+		Msg("embedding texts")
+
+	for start := 0; start < len(missing); start += c.batchSize {
+		end := start + c.batchSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		batch := make([]string, 0, end-start)
+		for _, index := range missing[start:end] {
+			batch = append(batch, texts[index])
+		}
+
+		batchVectors, err := c.requestWithRetry(batch)
+		if err != nil {
+			return nil, errors.NewLLMError("failed to fetch embeddings", err)
+		}
+
+		for i, index := range missing[start:end] {
+			vectors[index] = batchVectors[i]
+			if err := c.writeCache(texts[index], batchVectors[i]); err != nil {
+				c.logger.Debug().Err(err).Msg("failed to cache embedding")
+			}
+		}
+	}
+
+	return vectors, nil
+}
+
+// requestWithRetry requests a batch of embeddings, retrying transient failures
+func (c *Client) requestWithRetry(batch []string) ([][]float64, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			c.logger.Warn().Int("attempt", attempt).Err(lastErr).Msg("retrying embeddings request")
+			time.Sleep(c.retryDelay)
+		}
+
+		vectors, err := c.request(batch)
+		if err == nil {
+			return vectors, nil
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("all retry attempts exhausted: %w", lastErr)
+}
+
+// request makes a single /embeddings call
+func (c *Client) request(batch []string) ([][]float64, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model": c.model,
+		"input": batch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", c.baseURL+"/embeddings", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API returned non-200 status: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+
+	var apiResponse struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal API response: %w", err)
+	}
+	if len(apiResponse.Data) != len(batch) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(batch), len(apiResponse.Data))
+	}
+
+	vectors := make([][]float64, len(batch))
+	for _, item := range apiResponse.Data {
+		if item.Index < 0 || item.Index >= len(batch) {
+			return nil, fmt.Errorf("embedding index %d out of range", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+
+	return vectors, nil
+}
+
+// cachePath returns the cache file for a text under the current model
+func (c *Client) cachePath(text string) string {
+	sum := sha256.Sum256([]byte(c.model + "\n" + text))
+	return filepath.Join(c.cacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// readCache returns a cached vector if present
+func (c *Client) readCache(text string) ([]float64, bool) {
+	data, err := os.ReadFile(c.cachePath(text))
+	if err != nil {
+		return nil, false
+	}
+
+	var vector []float64
+	if err := json.Unmarshal(data, &vector); err != nil || len(vector) == 0 {
+		return nil, false
+	}
+	return vector, true
+}
+
+// writeCache stores a vector on disk
+func (c *Client) writeCache(text string, vector []float64) error {
+	if err := os.MkdirAll(c.cacheDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(vector)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.cachePath(text), data, 0644)
+}
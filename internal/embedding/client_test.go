@@ -0,0 +1,103 @@
+package embedding
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/config"
+)
+
+func newTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	c := New(config.LLMConfig{EmbeddingBaseURL: baseURL, EmbeddingModel: "test-embed", TimeoutSeconds: 5}, "test-key", zerolog.Nop())
+	c.cacheDir = t.TempDir()
+	c.batchSize = 2
+	c.retryDelay = 0
+	return c
+}
+
+// embeddingServer returns each input's length as a one-dimensional vector,
+// listing results in reverse order to exercise index handling
+func embeddingServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.URL.Path != "/embeddings" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+
+		var body struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if body.Model != "test-embed" {
+			t.Errorf("expected model test-embed, got %s", body.Model)
+		}
+		if len(body.Input) > 2 {
+			t.Errorf("expected batches of at most 2, got %d", len(body.Input))
+		}
+
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		}
+		var data []item
+		for i := len(body.Input) - 1; i >= 0; i-- {
+			data = append(data, item{Index: i, Embedding: []float64{float64(len(body.Input[i]))}})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+}
+
+func TestEmbed_BatchesAndCaches(t *testing.T) {
+	var requests int32
+	server := embeddingServer(t, &requests)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	texts := []string{"a", "bb", "ccc"}
+
+	vectors, err := client.Embed(texts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, text := range texts {
+		if len(vectors[i]) != 1 || vectors[i][0] != float64(len(text)) {
+			t.Errorf("vector %d = %v, want [%d]", i, vectors[i], len(text))
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 batched requests, got %d", requests)
+	}
+
+	if _, err := client.Embed(append(texts, "dddd")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected only the uncached text to be requested, got %d requests", requests)
+	}
+}
+
+func TestEmbed_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.maxRetries = 1
+
+	if _, err := client.Embed([]string{"a"}); err == nil {
+		t.Error("expected error from failing endpoint")
+	}
+}
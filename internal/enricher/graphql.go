@@ -0,0 +1,330 @@
+package enricher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/errors"
+	"ai-repo-insights/internal/models"
+)
+
+const (
+	graphQLURL   = "https://api.github.com/graphql"
+	maxBatchSize = 100
+	maxRetries   = 3
+	retryDelay   = 2 * time.Second
+)
+
+// repoFields is the GraphQL fragment requested for every aliased repository
+const repoFields = `fragment RepoFields on Repository {
+  id
+  stargazerCount
+  forkCount
+  createdAt
+  pushedAt
+  isArchived
+  isFork
+  watchers { totalCount }
+  primaryLanguage { name }
+  licenseInfo { spdxId name }
+  repositoryTopics(first: 20) { nodes { topic { name } } }
+}`
+
+// GraphQLEnricher fills in repository metadata using batched GitHub GraphQL queries
+type GraphQLEnricher struct {
+	token      string
+	endpoint   string
+	batchSize  int
+	httpClient *http.Client
+	logger     zerolog.Logger
+	// rateLimit is the last rate limit response; no request is sent before it resets
+	rateLimit *errors.AppError
+}
+
+// New creates a new GraphQLEnricher authenticated with the given token
+func New(token string, logger zerolog.Logger) *GraphQLEnricher {
+	return &GraphQLEnricher{
+		token:     token,
+		endpoint:  graphQLURL,
+		batchSize: maxBatchSize,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// graphQLRepository mirrors the RepoFields fragment in the response
+type graphQLRepository struct {
+	ID             string    `json:"id"`
+	StargazerCount int       `json:"stargazerCount"`
+	ForkCount      int       `json:"forkCount"`
+	CreatedAt      time.Time `json:"createdAt"`
+	PushedAt       time.Time `json:"pushedAt"`
+	IsArchived     bool      `json:"isArchived"`
+	IsFork         bool      `json:"isFork"`
+	Watchers       struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"watchers"`
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	LicenseInfo *struct {
+		SpdxID string `json:"spdxId"`
+		Name   string `json:"name"`
+	} `json:"licenseInfo"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+}
+
+// graphQLError is a single entry of the GraphQL "errors" array
+type graphQLError struct {
+	Type    string        `json:"type"`
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+// graphQLResponse is the top-level GraphQL response envelope
+type graphQLResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []graphQLError             `json:"errors"`
+}
+
+// Enrich fetches metadata for all repositories in batches of up to 100 aliases per query.
+// Repositories that cannot be resolved are returned unchanged with Enriched left false.
+// An error is returned only when no batch could be fetched at all.
+func (e *GraphQLEnricher) Enrich(repos []models.RepoMetadata) ([]models.RepoMetadata, error) {
+	enriched := make([]models.RepoMetadata, len(repos))
+	copy(enriched, repos)
+
+	if len(enriched) == 0 {
+		return enriched, nil
+	}
+
+	e.logger.Info().Int("repos", len(enriched)).Int("batch_size", e.batchSize).Msg("Starting GraphQL metadata enrichment")
+
+	var lastErr error
+	failedBatches := 0
+	resolved := 0
+
+	for start := 0; start < len(enriched); start += e.batchSize {
+		end := start + e.batchSize
+		if end > len(enriched) {
+			end = len(enriched)
+		}
+
+		count, err := e.enrichBatch(enriched[start:end])
+		if err != nil {
+			e.logger.Error().Int("batch_start", start).Int("batch_end", end).Err(err).Msg("Failed to enrich batch")
+			lastErr = err
+			failedBatches++
+			continue
+		}
+		resolved += count
+	}
+
+	batches := (len(enriched) + e.batchSize - 1) / e.batchSize
+	if failedBatches == batches {
+		return enriched, errors.NewDataFetchError("failed to enrich repository metadata", lastErr)
+	}
+
+	e.logger.Info().
+		Int("resolved", resolved).
+		Int("unresolved", len(enriched)-resolved).
+		Msg("GraphQL metadata enrichment completed")
+
+	return enriched, nil
+}
+
+// enrichBatch enriches a single batch in place and returns the number of resolved repositories
+func (e *GraphQLEnricher) enrichBatch(batch []models.RepoMetadata) (int, error) {
+	query, variables := buildQuery(batch)
+
+	var resp *graphQLResponse
+	var err error
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		resp, err = e.execute(query, variables)
+		if err == nil {
+			break
+		}
+		if appErr, ok := err.(*errors.AppError); ok && (!appErr.IsRetryable() || errors.IsRateLimit(err)) {
+			return 0, err
+		}
+		e.logger.Warn().Int("attempt", attempt).Err(err).Msg("GraphQL request failed")
+		if attempt < maxRetries {
+			time.Sleep(retryDelay * time.Duration(attempt))
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// Index per-alias errors so unresolved repos can be logged with a reason
+	aliasErrors := make(map[string]string)
+	for _, gqlErr := range resp.Errors {
+		if len(gqlErr.Path) == 0 {
+			continue
+		}
+		if alias, ok := gqlErr.Path[0].(string); ok {
+			aliasErrors[alias] = gqlErr.Message
+		}
+	}
+
+	resolved := 0
+	for i := range batch {
+		alias := aliasFor(i)
+		raw, exists := resp.Data[alias]
+		if !exists || string(raw) == "null" {
+			e.logger.Warn().
+				Str("repo", batch[i].Key()).
+				Str("reason", aliasErrors[alias]).
+				Msg("Repository could not be resolved")
+			continue
+		}
+
+		var repo graphQLRepository
+		if err := json.Unmarshal(raw, &repo); err != nil {
+			e.logger.Warn().Str("repo", batch[i].Key()).Err(err).Msg("Failed to decode repository metadata")
+			continue
+		}
+
+		applyMetadata(&batch[i], repo)
+		resolved++
+	}
+
+	return resolved, nil
+}
+
+// execute sends a single GraphQL request
+func (e *GraphQLEnricher) execute(query string, variables map[string]string) (*graphQLResponse, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", e.endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+e.token)
+
+	if e.rateLimit != nil {
+		reset, known := e.rateLimit.ResetTime()
+		if !known || time.Now().Before(reset) {
+			return nil, e.rateLimit
+		}
+		e.rateLimit = nil
+	}
+
+	httpResp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.NewNetworkError("failed to execute GraphQL request", err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, errors.NewNetworkError("failed to read GraphQL response", err)
+	}
+
+	if rateLimit := errors.RateLimitFromResponse(httpResp, time.Now()); rateLimit != nil {
+		e.logger.Warn().Str("reset_time", rateLimit.Context["reset_time"]).Msg("GitHub rate limit reached, no further GraphQL requests until reset")
+		e.rateLimit = rateLimit
+		return nil, rateLimit
+	}
+
+	switch {
+	case httpResp.StatusCode == http.StatusUnauthorized:
+		return nil, errors.NewConfigError("GitHub token rejected by GraphQL API", nil)
+	case httpResp.StatusCode != http.StatusOK:
+		return nil, errors.NewNetworkError(fmt.Sprintf("GraphQL API returned status %d", httpResp.StatusCode), nil)
+	}
+
+	var resp graphQLResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal GraphQL response: %w", err)
+	}
+
+	// A response with errors but no data at all means the whole query failed
+	if resp.Data == nil && len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL query failed: %s", resp.Errors[0].Message)
+	}
+
+	return &resp, nil
+}
+
+// buildQuery builds an aliased multi-repository query and its variables
+func buildQuery(batch []models.RepoMetadata) (string, map[string]string) {
+	var params []string
+	var fields []string
+	variables := make(map[string]string, len(batch)*2)
+
+	for i, repo := range batch {
+		owner := fmt.Sprintf("o%d", i)
+		name := fmt.Sprintf("n%d", i)
+		params = append(params, fmt.Sprintf("$%s: String!, $%s: String!", owner, name))
+		fields = append(fields, fmt.Sprintf("  %s: repository(owner: $%s, name: $%s) { ...RepoFields }", aliasFor(i), owner, name))
+		variables[owner] = repo.Owner
+		variables[name] = repo.Name
+	}
+
+	query := fmt.Sprintf("query(%s) {\n%s\n}\n%s",
+		strings.Join(params, ", "),
+		strings.Join(fields, "\n"),
+		repoFields,
+	)
+
+	return query, variables
+}
+
+// aliasFor returns the GraphQL alias used for the i-th repository of a batch
+func aliasFor(i int) string {
+	return fmt.Sprintf("r%d", i)
+}
+
+// applyMetadata copies GraphQL repository fields onto the repo metadata
+func applyMetadata(repo *models.RepoMetadata, data graphQLRepository) {
+	repo.ID = data.ID
+	repo.Stars = data.StargazerCount
+	repo.Forks = data.ForkCount
+	repo.CreatedAt = data.CreatedAt
+	repo.PushedAt = data.PushedAt
+	repo.IsArchived = data.IsArchived
+	repo.IsFork = data.IsFork
+	repo.Watchers = data.Watchers.TotalCount
+
+	if data.PrimaryLanguage != nil {
+		repo.PrimaryLanguage = data.PrimaryLanguage.Name
+	}
+
+	if data.LicenseInfo != nil {
+		repo.License = data.LicenseInfo.SpdxID
+		if repo.License == "" || repo.License == "NOASSERTION" {
+			repo.License = data.LicenseInfo.Name
+		}
+	}
+
+	topics := make([]string, 0, len(data.RepositoryTopics.Nodes))
+	for _, node := range data.RepositoryTopics.Nodes {
+		topics = append(topics, node.Topic.Name)
+	}
+	repo.Topics = topics
+
+	repo.Enriched = true
+}
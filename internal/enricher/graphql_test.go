@@ -0,0 +1,324 @@
+package enricher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/models"
+)
+
+// graphQLRequest is the request body received by the stand-in server
+type graphQLRequest struct {
+	Query     string            `json:"query"`
+	Variables map[string]string `json:"variables"`
+}
+
+// newTestServer starts a stand-in GraphQL server that resolves every repo except the ones in missing
+func newTestServer(t *testing.T, missing map[string]bool, requests *int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		data := make(map[string]interface{})
+		var errs []map[string]interface{}
+		for i := 0; ; i++ {
+			owner, ok := req.Variables[fmt.Sprintf("o%d", i)]
+			if !ok {
+				break
+			}
+			name := req.Variables[fmt.Sprintf("n%d", i)]
+			alias := fmt.Sprintf("r%d", i)
+
+			if !strings.Contains(req.Query, alias+": repository(") {
+				t.Errorf("query missing alias %s", alias)
+			}
+
+			if missing[owner+"/"+name] {
+				data[alias] = nil
+				errs = append(errs, map[string]interface{}{
+					"type":    "NOT_FOUND",
+					"path":    []string{alias},
+					"message": "Could not resolve to a Repository",
+				})
+				continue
+			}
+
+			data[alias] = map[string]interface{}{
+				"id":              fmt.Sprintf("R_%s", owner),
+				"stargazerCount":  1000 + i,
+				"forkCount":       10 + i,
+				"createdAt":       "2025-01-02T03:04:05Z",
+				"pushedAt":        "2026-06-01T00:00:00Z",
+				"isArchived":      false,
+				"isFork":          name == "forked",
+				"watchers":        map[string]int{"totalCount": 50},
+				"primaryLanguage": map[string]string{"name": "Go"},
+				"licenseInfo":     map[string]string{"spdxId": "MIT", "name": "MIT License"},
+				"repositoryTopics": map[string]interface{}{
+					"nodes": []map[string]interface{}{
+						{"topic": map[string]string{"name": "llm"}},
+						{"topic": map[string]string{"name": "agent"}},
+					},
+				},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "errors": errs})
+	}))
+}
+
+func newTestEnricher(endpoint string) *GraphQLEnricher {
+	e := New("test-token", zerolog.Nop())
+	e.endpoint = endpoint
+	return e
+}
+
+func TestEnrich_PopulatesMetadata(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, nil, &requests)
+	defer server.Close()
+
+	e := newTestEnricher(server.URL)
+	repos := []models.RepoMetadata{
+		{Owner: "owner1", Name: "repo1", Language: "go"},
+		{Owner: "owner2", Name: "forked", Language: "python"},
+	}
+
+	enriched, err := e.Enrich(repos)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(enriched) != 2 {
+		t.Fatalf("expected 2 repos, got %d", len(enriched))
+	}
+
+	first := enriched[0]
+	if !first.Enriched {
+		t.Error("expected first repo to be marked enriched")
+	}
+	if first.Stars != 1000 {
+		t.Errorf("expected 1000 stars, got %d", first.Stars)
+	}
+	if first.Forks != 10 {
+		t.Errorf("expected 10 forks, got %d", first.Forks)
+	}
+	if first.CreatedAt.Year() != 2025 {
+		t.Errorf("expected created_at in 2025, got %v", first.CreatedAt)
+	}
+	if first.PushedAt.Year() != 2026 {
+		t.Errorf("expected pushed_at in 2026, got %v", first.PushedAt)
+	}
+	if first.ID != "R_owner1" {
+		t.Errorf("expected ID R_owner1, got %s", first.ID)
+	}
+	if first.Watchers != 50 {
+		t.Errorf("expected 50 watchers, got %d", first.Watchers)
+	}
+	if first.PrimaryLanguage != "Go" {
+		t.Errorf("expected primary language Go, got %s", first.PrimaryLanguage)
+	}
+	if first.Language != "go" {
+		t.Errorf("expected trending language to be preserved, got %s", first.Language)
+	}
+	if first.License != "MIT" {
+		t.Errorf("expected license MIT, got %s", first.License)
+	}
+	if len(first.Topics) != 2 || first.Topics[0] != "llm" {
+		t.Errorf("expected topics [llm agent], got %v", first.Topics)
+	}
+
+	if !enriched[1].IsFork {
+		t.Error("expected second repo to be marked as fork")
+	}
+
+	// Input slice must not be modified
+	if repos[0].Enriched {
+		t.Error("expected input repos to be left untouched")
+	}
+}
+
+func TestEnrich_PartialFailure(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, map[string]bool{"gone/deleted": true}, &requests)
+	defer server.Close()
+
+	e := newTestEnricher(server.URL)
+	repos := []models.RepoMetadata{
+		{Owner: "owner1", Name: "repo1", Stars: 5},
+		{Owner: "gone", Name: "deleted", Stars: 7},
+		{Owner: "owner3", Name: "repo3"},
+	}
+
+	enriched, err := e.Enrich(repos)
+	if err != nil {
+		t.Fatalf("expected partial failure to succeed, got %v", err)
+	}
+
+	if !enriched[0].Enriched || !enriched[2].Enriched {
+		t.Error("expected resolvable repos to be enriched")
+	}
+	if enriched[1].Enriched {
+		t.Error("expected unresolvable repo to stay unenriched")
+	}
+	if enriched[1].Stars != 7 {
+		t.Errorf("expected unresolvable repo to keep original stars, got %d", enriched[1].Stars)
+	}
+	if enriched[2].Stars != 1002 {
+		t.Errorf("expected alias r2 data on third repo, got %d stars", enriched[2].Stars)
+	}
+}
+
+func TestEnrich_Batching(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, nil, &requests)
+	defer server.Close()
+
+	e := newTestEnricher(server.URL)
+	e.batchSize = 2
+
+	repos := []models.RepoMetadata{
+		{Owner: "a", Name: "one"},
+		{Owner: "b", Name: "two"},
+		{Owner: "c", Name: "three"},
+		{Owner: "d", Name: "four"},
+		{Owner: "e", Name: "five"},
+	}
+
+	enriched, err := e.Enrich(repos)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("expected 3 batched requests, got %d", got)
+	}
+
+	for _, repo := range enriched {
+		if !repo.Enriched {
+			t.Errorf("expected %s to be enriched", repo.Key())
+		}
+	}
+
+	// Aliases restart per batch, so the fifth repo is r0 of the third batch
+	if enriched[4].Stars != 1000 {
+		t.Errorf("expected fifth repo to have 1000 stars, got %d", enriched[4].Stars)
+	}
+}
+
+func TestEnrich_AllBatchesFail(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, nil, &requests)
+	defer server.Close()
+
+	e := New("wrong-token", zerolog.Nop())
+	e.endpoint = server.URL
+
+	repos := []models.RepoMetadata{{Owner: "owner1", Name: "repo1", Stars: 3}}
+
+	enriched, err := e.Enrich(repos)
+	if err == nil {
+		t.Fatal("expected error when every batch fails")
+	}
+	if len(enriched) != 1 || enriched[0].Stars != 3 {
+		t.Errorf("expected original repos to be returned on failure, got %+v", enriched)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected auth failure not to be retried, got %d requests", got)
+	}
+}
+
+func TestEnrich_RateLimited(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header map[string]string
+	}{
+		{name: "primary limit", status: http.StatusForbidden, header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "4102444800"}},
+		{name: "secondary limit", status: http.StatusForbidden, header: map[string]string{"Retry-After": "3600"}},
+		{name: "too many requests", status: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				for key, value := range tt.header {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			e := newTestEnricher(server.URL)
+			e.batchSize = 1
+
+			repos := []models.RepoMetadata{{Owner: "a", Name: "one"}, {Owner: "b", Name: "two"}}
+			if _, err := e.Enrich(repos); err == nil {
+				t.Fatal("expected error when rate limited")
+			}
+			// Neither a retry nor the next batch may be sent before the limit resets
+			if got := atomic.LoadInt32(&requests); got != 1 {
+				t.Errorf("expected 1 request, got %d", got)
+			}
+		})
+	}
+}
+
+func TestEnrich_EmptyInput(t *testing.T) {
+	e := New("test-token", zerolog.Nop())
+
+	enriched, err := e.Enrich(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(enriched) != 0 {
+		t.Errorf("expected no repos, got %d", len(enriched))
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	batch := []models.RepoMetadata{
+		{Owner: "owner1", Name: "repo1"},
+		{Owner: "owner2", Name: "repo2"},
+	}
+
+	query, variables := buildQuery(batch)
+
+	for _, want := range []string{
+		"$o0: String!, $n0: String!",
+		"r0: repository(owner: $o0, name: $n0) { ...RepoFields }",
+		"r1: repository(owner: $o1, name: $n1) { ...RepoFields }",
+		"fragment RepoFields on Repository",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("expected query to contain %q, got:\n%s", want, query)
+		}
+	}
+
+	if variables["o1"] != "owner2" || variables["n1"] != "repo2" {
+		t.Errorf("unexpected variables: %v", variables)
+	}
+}
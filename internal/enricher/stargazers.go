@@ -0,0 +1,156 @@
+package enricher
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"ai-repo-insights/internal/errors"
+	"ai-repo-insights/internal/models"
+)
+
+const (
+	// stargazerBatchSize keeps stargazer queries well under GitHub's node limit
+	stargazerBatchSize = 20
+	// maxStargazerSample is the most stargazers GitHub returns per connection page
+	maxStargazerSample = 100
+)
+
+// graphQLStargazers mirrors the stargazer sample requested for each aliased repository
+type graphQLStargazers struct {
+	Stargazers struct {
+		Edges []struct {
+			StarredAt time.Time `json:"starredAt"`
+			Node      struct {
+				CreatedAt time.Time `json:"createdAt"`
+				Followers struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"followers"`
+				Repositories struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"repositories"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"stargazers"`
+}
+
+// SampleStargazers fetches the most recent stargazers of each repository, up to
+// sampleSize (at most 100) per repository, keyed by repository key. Repositories that
+// cannot be resolved are left out. An error is returned only when no batch could be
+// fetched at all.
+func (e *GraphQLEnricher) SampleStargazers(repos []models.RepoMetadata, sampleSize int) (map[string][]models.Stargazer, error) {
+	samples := make(map[string][]models.Stargazer, len(repos))
+	if len(repos) == 0 || sampleSize <= 0 {
+		return samples, nil
+	}
+	if sampleSize > maxStargazerSample {
+		sampleSize = maxStargazerSample
+	}
+
+	var lastErr error
+	failedBatches := 0
+
+	for start := 0; start < len(repos); start += stargazerBatchSize {
+		end := start + stargazerBatchSize
+		if end > len(repos) {
+			end = len(repos)
+		}
+
+		if err := e.sampleBatch(repos[start:end], sampleSize, samples); err != nil {
+			e.logger.Error().Int("batch_start", start).Int("batch_end", end).Err(err).Msg("Failed to sample stargazers")
+			lastErr = err
+			failedBatches++
+		}
+	}
+
+	batches := (len(repos) + stargazerBatchSize - 1) / stargazerBatchSize
+	if failedBatches == batches {
+		return samples, errors.NewDataFetchError("failed to sample stargazers", lastErr)
+	}
+
+	e.logger.Info().Int("repos", len(repos)).Int("sampled", len(samples)).Msg("Stargazer sampling completed")
+
+	return samples, nil
+}
+
+// sampleBatch samples the stargazers of a single batch into samples
+func (e *GraphQLEnricher) sampleBatch(batch []models.RepoMetadata, sampleSize int, samples map[string][]models.Stargazer) error {
+	query, variables := buildStargazerQuery(batch, sampleSize)
+
+	var resp *graphQLResponse
+	var err error
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		resp, err = e.execute(query, variables)
+		if err == nil {
+			break
+		}
+		if appErr, ok := err.(*errors.AppError); ok && (!appErr.IsRetryable() || errors.IsRateLimit(err)) {
+			return err
+		}
+		e.logger.Warn().Int("attempt", attempt).Err(err).Msg("GraphQL request failed")
+		if attempt < maxRetries {
+			time.Sleep(retryDelay * time.Duration(attempt))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range batch {
+		raw, exists := resp.Data[aliasFor(i)]
+		if !exists || string(raw) == "null" {
+			continue
+		}
+
+		var data graphQLStargazers
+		if err := json.Unmarshal(raw, &data); err != nil {
+			e.logger.Warn().Str("repo", batch[i].Key()).Err(err).Msg("Failed to decode stargazers")
+			continue
+		}
+
+		sample := make([]models.Stargazer, 0, len(data.Stargazers.Edges))
+		for _, edge := range data.Stargazers.Edges {
+			sample = append(sample, models.Stargazer{
+				StarredAt:        edge.StarredAt,
+				AccountCreatedAt: edge.Node.CreatedAt,
+				Followers:        edge.Node.Followers.TotalCount,
+				Repositories:     edge.Node.Repositories.TotalCount,
+			})
+		}
+		samples[batch[i].Key()] = sample
+	}
+
+	return nil
+}
+
+// buildStargazerQuery builds an aliased query for the latest stargazers of each repository
+func buildStargazerQuery(batch []models.RepoMetadata, sampleSize int) (string, map[string]string) {
+	var params []string
+	var fields []string
+	variables := make(map[string]string, len(batch)*2)
+
+	for i, repo := range batch {
+		owner := fmt.Sprintf("o%d", i)
+		name := fmt.Sprintf("n%d", i)
+		params = append(params, fmt.Sprintf("$%s: String!, $%s: String!", owner, name))
+		fields = append(fields, fmt.Sprintf("  %s: repository(owner: $%s, name: $%s) { ...StargazerFields }", aliasFor(i), owner, name))
+		variables[owner] = repo.Owner
+		variables[name] = repo.Name
+	}
+
+	query := fmt.Sprintf(`query(%s) {
+%s
+}
+fragment StargazerFields on Repository {
+  stargazers(last: %d, orderBy: {field: STARRED_AT, direction: ASC}) {
+    edges { starredAt node { createdAt followers { totalCount } repositories { totalCount } } }
+  }
+}`,
+		strings.Join(params, ", "),
+		strings.Join(fields, "\n"),
+		sampleSize,
+	)
+
+	return query, variables
+}
@@ -0,0 +1,118 @@
+package enricher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"ai-repo-insights/internal/models"
+)
+
+func newStargazerServer(t *testing.T, requests *int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !strings.Contains(req.Query, "stargazers(last: 2") {
+			t.Errorf("expected sample size in query, got:\n%s", req.Query)
+		}
+
+		data := make(map[string]interface{})
+		for i := 0; ; i++ {
+			name, ok := req.Variables[fmt.Sprintf("n%d", i)]
+			if !ok {
+				break
+			}
+			alias := fmt.Sprintf("r%d", i)
+			if name == "deleted" {
+				data[alias] = nil
+				continue
+			}
+			data[alias] = map[string]interface{}{
+				"stargazers": map[string]interface{}{
+					"edges": []map[string]interface{}{
+						{
+							"starredAt": "2026-06-01T10:00:00Z",
+							"node": map[string]interface{}{
+								"createdAt":    "2026-05-30T00:00:00Z",
+								"followers":    map[string]int{"totalCount": 0},
+								"repositories": map[string]int{"totalCount": 0},
+							},
+						},
+						{
+							"starredAt": "2026-06-01T10:05:00Z",
+							"node": map[string]interface{}{
+								"createdAt":    "2015-01-01T00:00:00Z",
+								"followers":    map[string]int{"totalCount": 12},
+								"repositories": map[string]int{"totalCount": 30},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+}
+
+func TestSampleStargazers(t *testing.T) {
+	var requests int32
+	server := newStargazerServer(t, &requests)
+	defer server.Close()
+
+	e := newTestEnricher(server.URL)
+	repos := []models.RepoMetadata{
+		{Owner: "owner1", Name: "repo1"},
+		{Owner: "gone", Name: "deleted"},
+	}
+
+	samples, err := e.SampleStargazers(repos, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, exists := samples["gone/deleted"]; exists {
+		t.Error("expected unresolvable repo to be left out")
+	}
+
+	sample := samples["owner1/repo1"]
+	if len(sample) != 2 {
+		t.Fatalf("expected 2 stargazers, got %d", len(sample))
+	}
+	if sample[0].AccountCreatedAt.Year() != 2026 || sample[0].Followers != 0 {
+		t.Errorf("unexpected first stargazer: %+v", sample[0])
+	}
+	if sample[1].Followers != 12 || sample[1].Repositories != 30 || sample[1].StarredAt.Minute() != 5 {
+		t.Errorf("unexpected second stargazer: %+v", sample[1])
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestSampleStargazers_EmptyInput(t *testing.T) {
+	var requests int32
+	server := newStargazerServer(t, &requests)
+	defer server.Close()
+
+	samples, err := newTestEnricher(server.URL).SampleStargazers(nil, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(samples) != 0 || atomic.LoadInt32(&requests) != 0 {
+		t.Errorf("expected no samples and no requests, got %d samples", len(samples))
+	}
+}
@@ -2,6 +2,9 @@ package errors
 
 import (
 	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 )
 
 // ErrorType represents different categories of errors in the system
@@ -116,6 +119,46 @@ func NewRateLimitError(resetTime string) *AppError {
 	}
 }
 
+// RateLimitFromResponse returns a rate limit error when a 403 or 429 response is GitHub
+// throttling the client, or nil otherwise. Retry-After takes precedence over
+// X-RateLimit-Reset; a 403 without either header is an ordinary permission error.
+func RateLimitFromResponse(resp *http.Response, now time.Time) *AppError {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return NewRateLimitError(now.Add(time.Duration(seconds) * time.Second).UTC().Format(time.RFC3339))
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		resetTime := ""
+		if epoch, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			resetTime = time.Unix(epoch, 0).UTC().Format(time.RFC3339)
+		}
+		return NewRateLimitError(resetTime)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return NewRateLimitError("")
+	}
+
+	return nil
+}
+
+// IsRateLimit returns true if err is a rate limit error
+func IsRateLimit(err error) bool {
+	appErr, ok := err.(*AppError)
+	return ok && appErr.Type == ErrorTypeRateLimit
+}
+
+// ResetTime returns when a rate limit error allows requests again, or false when unknown
+func (e *AppError) ResetTime() (time.Time, bool) {
+	reset, err := time.Parse(time.RFC3339, e.Context["reset_time"])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return reset, true
+}
+
 // NewNetworkError creates a new network error
 func NewNetworkError(message string, err error) *AppError {
 	return &AppError{
@@ -0,0 +1,246 @@
+package evaluation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"ai-repo-insights/internal/classifier"
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/errors"
+	"ai-repo-insights/internal/models"
+)
+
+// ExcludedLabel is the confusion matrix label for repositories that are not included
+const ExcludedLabel = "(excluded)"
+
+// LabeledRepo is one line of an evaluation dataset: repository metadata and
+// the expected classification
+type LabeledRepo struct {
+	Metadata models.RepoMetadata `json:"metadata"`
+	Include  bool                `json:"include"`
+	Category string              `json:"category,omitempty"`
+}
+
+// Metrics holds counts and scores for one binary decision
+type Metrics struct {
+	TruePositives  int     `json:"true_positives"`
+	FalsePositives int     `json:"false_positives"`
+	FalseNegatives int     `json:"false_negatives"`
+	Precision      float64 `json:"precision"`
+	Recall         float64 `json:"recall"`
+	F1             float64 `json:"f1"`
+}
+
+// CategoryMetrics holds the metrics of one primary category
+type CategoryMetrics struct {
+	Name string `json:"name"`
+	Metrics
+}
+
+// ConfusionMatrix counts expected labels (rows) against predicted labels (columns)
+type ConfusionMatrix struct {
+	Labels []string `json:"labels"`
+	Counts [][]int  `json:"counts"`
+}
+
+// Misclassification describes a repository whose prediction differs from its label
+type Misclassification struct {
+	Repo     string `json:"repo"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// Report is the result of evaluating a classifier against a dataset
+type Report struct {
+	Total         int                 `json:"total"`
+	Accuracy      float64             `json:"accuracy"`
+	Inclusion     Metrics             `json:"inclusion"`
+	Categories    []CategoryMetrics   `json:"categories"`
+	Confusion     ConfusionMatrix     `json:"confusion"`
+	Misclassified []Misclassification `json:"misclassified"`
+}
+
+// LoadDataset reads a JSONL dataset, skipping blank lines
+func LoadDataset(path string) ([]LabeledRepo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.NewFilesystemError("failed to open dataset", path, err)
+	}
+	defer file.Close()
+
+	var examples []LabeledRepo
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var example LabeledRepo
+		if err := json.Unmarshal([]byte(line), &example); err != nil {
+			return nil, errors.NewFilesystemError(fmt.Sprintf("invalid example on line %d", lineNumber), path, err)
+		}
+		if example.Metadata.Owner == "" || example.Metadata.Name == "" {
+			return nil, errors.NewFilesystemError(fmt.Sprintf("example on line %d has no metadata owner/name", lineNumber), path, nil)
+		}
+		if !example.Include && example.Category != "" {
+			return nil, errors.NewFilesystemError(fmt.Sprintf("example on line %d has a category but is not included", lineNumber), path, nil)
+		}
+		examples = append(examples, example)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.NewFilesystemError("failed to read dataset", path, err)
+	}
+
+	return examples, nil
+}
+
+// Evaluate classifies the dataset and compares the results with the labels.
+// categories fixes the order of per-category metrics and confusion matrix labels.
+func Evaluate(repoClassifier classifier.RepoClassifier, categories []string, examples []LabeledRepo) Report {
+	repos := make([]models.RepoMetadata, 0, len(examples))
+	for _, example := range examples {
+		repos = append(repos, example.Metadata)
+	}
+
+	predicted := make(map[string]string, len(repos))
+	for _, repo := range repoClassifier.Classify(repos) {
+		predicted[strings.ToLower(repo.Key())] = categoryLabel(repo.PrimaryCategory)
+	}
+
+	labels := confusionLabels(categories, examples)
+	index := make(map[string]int, len(labels))
+	for i, label := range labels {
+		index[label] = i
+	}
+
+	report := Report{
+		Total:         len(examples),
+		Confusion:     ConfusionMatrix{Labels: labels, Counts: make([][]int, len(labels))},
+		Misclassified: []Misclassification{},
+	}
+	for i := range report.Confusion.Counts {
+		report.Confusion.Counts[i] = make([]int, len(labels))
+	}
+
+	correct := 0
+	for _, example := range examples {
+		expected := expectedLabel(example)
+		actual, included := predicted[strings.ToLower(example.Metadata.Key())]
+		if !included {
+			actual = ExcludedLabel
+		}
+
+		report.Confusion.Counts[index[expected]][index[actual]]++
+		countBinary(&report.Inclusion, example.Include, included)
+
+		if expected == actual {
+			correct++
+		} else {
+			report.Misclassified = append(report.Misclassified, Misclassification{
+				Repo:     example.Metadata.Key(),
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	if report.Total > 0 {
+		report.Accuracy = float64(correct) / float64(report.Total)
+	}
+	report.Inclusion.score()
+
+	// Per-category metrics treat each label except exclusion as a one-vs-rest decision
+	for _, label := range labels {
+		if label == ExcludedLabel {
+			continue
+		}
+		i := index[label]
+		metrics := CategoryMetrics{Name: label}
+		for j := range labels {
+			if j == i {
+				metrics.TruePositives = report.Confusion.Counts[i][j]
+				continue
+			}
+			metrics.FalseNegatives += report.Confusion.Counts[i][j]
+			metrics.FalsePositives += report.Confusion.Counts[j][i]
+		}
+		metrics.score()
+		report.Categories = append(report.Categories, metrics)
+	}
+
+	sort.SliceStable(report.Misclassified, func(i, j int) bool {
+		return report.Misclassified[i].Repo < report.Misclassified[j].Repo
+	})
+
+	return report
+}
+
+// countBinary adds one decision to an inclusion tally
+func countBinary(m *Metrics, expected bool, actual bool) {
+	switch {
+	case expected && actual:
+		m.TruePositives++
+	case !expected && actual:
+		m.FalsePositives++
+	case expected && !actual:
+		m.FalseNegatives++
+	}
+}
+
+// score fills in precision, recall and F1 from the counts; undefined ratios are 0
+func (m *Metrics) score() {
+	if m.TruePositives+m.FalsePositives > 0 {
+		m.Precision = float64(m.TruePositives) / float64(m.TruePositives+m.FalsePositives)
+	}
+	if m.TruePositives+m.FalseNegatives > 0 {
+		m.Recall = float64(m.TruePositives) / float64(m.TruePositives+m.FalseNegatives)
+	}
+	if m.Precision+m.Recall > 0 {
+		m.F1 = 2 * m.Precision * m.Recall / (m.Precision + m.Recall)
+	}
+}
+
+// expectedLabel returns the confusion matrix label of a dataset example
+func expectedLabel(example LabeledRepo) string {
+	if !example.Include {
+		return ExcludedLabel
+	}
+	return categoryLabel(example.Category)
+}
+
+// categoryLabel maps an empty category to the Other bucket
+func categoryLabel(category string) string {
+	if category == "" {
+		return config.OtherCategory
+	}
+	return category
+}
+
+// confusionLabels lists configured categories, then labels that only appear in
+// the dataset, then Other and the excluded label
+func confusionLabels(categories []string, examples []LabeledRepo) []string {
+	labels := append([]string{}, categories...)
+	known := make(map[string]bool, len(labels)+2)
+	for _, label := range labels {
+		known[label] = true
+	}
+	known[config.OtherCategory] = true
+	known[ExcludedLabel] = true
+
+	var extra []string
+	for _, example := range examples {
+		if label := expectedLabel(example); !known[label] {
+			known[label] = true
+			extra = append(extra, label)
+		}
+	}
+	sort.Strings(extra)
+
+	labels = append(labels, extra...)
+	return append(labels, config.OtherCategory, ExcludedLabel)
+}
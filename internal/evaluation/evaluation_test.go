@@ -0,0 +1,122 @@
+package evaluation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ai-repo-insights/internal/classifier"
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+func TestLoadDataset(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.jsonl")
+	content := `{"metadata": {"owner": "a", "name": "agent-kit"}, "include": true, "category": "agent"}
+
+{"metadata": {"owner": "a", "name": "game"}, "include": false}
+`
+	if err := os.WriteFile(valid, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	examples, err := LoadDataset(valid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(examples) != 2 || examples[0].Category != "agent" || examples[1].Include {
+		t.Errorf("unexpected examples: %+v", examples)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"bad json", `{"metadata": `, "line 1"},
+		{"missing name", `{"metadata": {"owner": "a"}, "include": true}`, "owner/name"},
+		{"category on excluded", "\n" + `{"metadata": {"owner": "a", "name": "b"}, "include": false, "category": "agent"}`, "line 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "invalid.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadDataset(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include: []string{"llm", "agent"},
+		Exclude: []string{"tutorial"},
+		Categories: map[string][]string{
+			"agent": {"agent"},
+			"rag":   {"retrieval"},
+		},
+	}
+	examples := []LabeledRepo{
+		{Metadata: models.RepoMetadata{Owner: "a", Name: "agent-kit", Description: "llm agent"}, Include: true, Category: "agent"},
+		{Metadata: models.RepoMetadata{Owner: "a", Name: "retriever", Description: "llm retrieval"}, Include: true, Category: "rag"},
+		// Labeled rag but the agent keyword wins
+		{Metadata: models.RepoMetadata{Owner: "a", Name: "search", Description: "agent search"}, Include: true, Category: "rag"},
+		// Missed: no include keyword
+		{Metadata: models.RepoMetadata{Owner: "a", Name: "vectors", Description: "vector retrieval"}, Include: true, Category: "rag"},
+		// False positive: included but labeled out of scope
+		{Metadata: models.RepoMetadata{Owner: "a", Name: "chat", Description: "llm chat ui"}, Include: false},
+		{Metadata: models.RepoMetadata{Owner: "a", Name: "course", Description: "llm tutorial"}, Include: false},
+	}
+
+	report := Evaluate(classifier.New(keywords), keywords.OrderedCategories(), examples)
+
+	if report.Total != 6 {
+		t.Errorf("expected 6 examples, got %d", report.Total)
+	}
+	if report.Accuracy != 3.0/6.0 {
+		t.Errorf("expected accuracy 0.5, got %f", report.Accuracy)
+	}
+
+	inclusion := report.Inclusion
+	if inclusion.TruePositives != 3 || inclusion.FalsePositives != 1 || inclusion.FalseNegatives != 1 {
+		t.Errorf("unexpected inclusion counts: %+v", inclusion)
+	}
+	if inclusion.Precision != 0.75 || inclusion.Recall != 0.75 || inclusion.F1 != 0.75 {
+		t.Errorf("unexpected inclusion scores: %+v", inclusion)
+	}
+
+	wantLabels := []string{"agent", "rag", config.OtherCategory, ExcludedLabel}
+	if strings.Join(report.Confusion.Labels, ",") != strings.Join(wantLabels, ",") {
+		t.Fatalf("expected labels %v, got %v", wantLabels, report.Confusion.Labels)
+	}
+	// Row rag: one correct, one predicted agent, one excluded
+	if got := report.Confusion.Counts[1]; got[0] != 1 || got[1] != 1 || got[3] != 1 {
+		t.Errorf("unexpected rag row %v", got)
+	}
+
+	var rag CategoryMetrics
+	for _, category := range report.Categories {
+		if category.Name == "rag" {
+			rag = category
+		}
+	}
+	if rag.TruePositives != 1 || rag.FalsePositives != 0 || rag.FalseNegatives != 2 {
+		t.Errorf("unexpected rag metrics: %+v", rag)
+	}
+	if rag.Precision != 1 || rag.Recall != 1.0/3.0 {
+		t.Errorf("unexpected rag scores: %+v", rag)
+	}
+
+	if len(report.Misclassified) != 3 {
+		t.Fatalf("expected 3 misclassified repos, got %+v", report.Misclassified)
+	}
+	if miss := report.Misclassified[0]; miss.Repo != "a/chat" || miss.Expected != ExcludedLabel || miss.Actual != config.OtherCategory {
+		t.Errorf("unexpected first misclassification: %+v", miss)
+	}
+}
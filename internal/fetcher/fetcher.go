@@ -21,20 +21,23 @@ const (
 	maxRetries = 3
 	retryDelay = 2 * time.Second
 	baseURL    = "https://github.com/trending"
+
+	// RawDir is where SaveRaw writes date-stamped trending snapshots
+	RawDir = "data/trending_raw"
 )
 
 // TrendingFetcher fetches trending repositories from GitHub
 type TrendingFetcher struct {
-	languages []string
-	client    *http.Client
-	logger    zerolog.Logger
+	languages  []string
+	httpClient *http.Client
+	logger     zerolog.Logger
 }
 
 // New creates a new TrendingFetcher
 func New(languages []string, logger zerolog.Logger) *TrendingFetcher {
 	return &TrendingFetcher{
 		languages: languages,
-		client: &http.Client{
+		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 		logger: logger,
@@ -81,7 +84,7 @@ func (f *TrendingFetcher) fetchTrendingForLanguage(language string) ([]models.Re
 	var lastErr error
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		// Fetch today, week, and month data
-		reposToday, err := f.scrapeTrendingPage(language, "daily")
+		reposToday, err := f.scrapeTrendingPage(language, models.WindowDaily)
 		if err != nil {
 			lastErr = err
 			f.logger.Warn().Str("language", language).Int("attempt", attempt).Err(err).Msg("Fetch attempt failed for today")
@@ -91,7 +94,7 @@ func (f *TrendingFetcher) fetchTrendingForLanguage(language string) ([]models.Re
 			continue
 		}
 
-		reposWeek, err := f.scrapeTrendingPage(language, "weekly")
+		reposWeek, err := f.scrapeTrendingPage(language, models.WindowWeekly)
 		if err != nil {
 			lastErr = err
 			f.logger.Warn().Str("language", language).Int("attempt", attempt).Err(err).Msg("Fetch attempt failed for week")
@@ -101,7 +104,7 @@ func (f *TrendingFetcher) fetchTrendingForLanguage(language string) ([]models.Re
 			continue
 		}
 
-		reposMonth, err := f.scrapeTrendingPage(language, "monthly")
+		reposMonth, err := f.scrapeTrendingPage(language, models.WindowMonthly)
 		if err != nil {
 			lastErr = err
 			f.logger.Warn().Str("language", language).Int("attempt", attempt).Err(err).Msg("Fetch attempt failed for month")
@@ -132,7 +135,7 @@ func (f *TrendingFetcher) scrapeTrendingPage(language string, since string) (map
 	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36")
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
 	
-	resp, err := f.client.Do(req)
+	resp, err := f.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch page: %w", err)
 	}
@@ -151,58 +154,69 @@ func (f *TrendingFetcher) scrapeTrendingPage(language string, since string) (map
 	
 	// Parse each repository article
 	doc.Find("article.Box-row").Each(func(i int, s *goquery.Selection) {
-		// Extract owner and repo name from h2 a[href]
-		repoLink := s.Find("h2 a").First()
-		href, exists := repoLink.Attr("href")
-		if !exists {
-			return
-		}
-		
-		// href format: /owner/repo
-		parts := strings.Split(strings.Trim(href, "/"), "/")
-		if len(parts) != 2 {
+		repo, err := f.extractRepoMetadata(s, language)
+		if err != nil {
+			f.logger.Debug().Err(err).Msg("Skipping unparseable trending entry")
 			return
 		}
-		
-		owner := parts[0]
-		name := parts[1]
-		key := owner + "/" + name
-		
-		// Extract description
-		description := strings.TrimSpace(s.Find("p.col-9").Text())
-		
-		// Extract stars gained
+
+		// Extract stars gained in this timeframe
 		starsText := s.Find("span.d-inline-block.float-sm-right").Text()
-		stars := f.parseStars(starsText)
-		
-		repos[key] = &models.RepoMetadata{
-			Owner:       owner,
-			Name:        name,
-			URL:         "https://github.com" + href,
-			Description: description,
-			Language:    language,
-			Topics:      []string{},
-			Stars:       0,
-			Forks:       0,
-			CreatedAt:   time.Now(),
-		}
-		
+		stars := f.parseStarCount(starsText)
+
 		// Store stars based on timeframe
 		switch since {
-		case "daily":
-			repos[key].StarsToday = stars
-		case "weekly":
-			repos[key].StarsThisWeek = stars
-		case "monthly":
-			repos[key].StarsThisMonth = stars
+		case models.WindowDaily:
+			repo.StarsToday = stars
+		case models.WindowWeekly:
+			repo.StarsThisWeek = stars
+		case models.WindowMonthly:
+			repo.StarsThisMonth = stars
 		}
+
+		repos[repo.Key()] = repo
 	})
 	
 	return repos, nil
 }
 
-// parseStars extracts the star count from text like "1,234 stars today"
-func (f *TrendingFetcher) parseStars(text string) int {
+// extractRepoMetadata extracts repository metadata from a trending article element
+func (f *TrendingFetcher) extractRepoMetadata(s *goquery.Selection, language string) (*models.RepoMetadata, error) {
+	// Extract owner and repo name from h2 a[href]
+	repoLink := s.Find("h2 a").First()
+	href, exists := repoLink.Attr("href")
+	if !exists {
+		return nil, fmt.Errorf("repository link not found")
+	}
+
+	// href format: /owner/repo
+	parts := strings.Split(strings.Trim(href, "/"), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repository href: %s", href)
+	}
+
+	topics := []string{}
+	s.Find("a.topic-tag").Each(func(i int, t *goquery.Selection) {
+		if topic := strings.TrimSpace(t.Text()); topic != "" {
+			topics = append(topics, topic)
+		}
+	})
+
+	return &models.RepoMetadata{
+		Owner:       parts[0],
+		Name:        parts[1],
+		URL:         "https://github.com" + href,
+		Description: strings.TrimSpace(s.Find("p.col-9").Text()),
+		Language:    language,
+		Topics:      topics,
+		Stars:       f.parseStarCount(s.Find(`a[href$="/stargazers"]`).Text()),
+		Forks:       f.parseStarCount(s.Find(`a[href$="/forks"]`).Text()),
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// parseStarCount extracts the star count from text like "1,234 stars today"
+func (f *TrendingFetcher) parseStarCount(text string) int {
 	// Remove commas and extract numbers
 	re := regexp.MustCompile(`[\d,]+`)
 	match := re.FindString(text)
@@ -255,7 +269,11 @@ func (f *TrendingFetcher) mergeRepos(
 			repo.Name = todayRepo.Name
 			repo.URL = todayRepo.URL
 			repo.Description = todayRepo.Description
+			repo.Topics = todayRepo.Topics
+			repo.Stars = todayRepo.Stars
+			repo.Forks = todayRepo.Forks
 			repo.StarsToday = todayRepo.StarsToday
+			repo.TrendingWindows = append(repo.TrendingWindows, models.WindowDaily)
 		}
 		
 		// Get data from week
@@ -265,8 +283,12 @@ func (f *TrendingFetcher) mergeRepos(
 				repo.Name = weekRepo.Name
 				repo.URL = weekRepo.URL
 				repo.Description = weekRepo.Description
+				repo.Topics = weekRepo.Topics
+				repo.Stars = weekRepo.Stars
+				repo.Forks = weekRepo.Forks
 			}
 			repo.StarsThisWeek = weekRepo.StarsThisWeek
+			repo.TrendingWindows = append(repo.TrendingWindows, models.WindowWeekly)
 		}
 		
 		// Get data from month
@@ -276,8 +298,12 @@ func (f *TrendingFetcher) mergeRepos(
 				repo.Name = monthRepo.Name
 				repo.URL = monthRepo.URL
 				repo.Description = monthRepo.Description
+				repo.Topics = monthRepo.Topics
+				repo.Stars = monthRepo.Stars
+				repo.Forks = monthRepo.Forks
 			}
 			repo.StarsThisMonth = monthRepo.StarsThisMonth
+			repo.TrendingWindows = append(repo.TrendingWindows, models.WindowMonthly)
 		}
 		
 		// Only add if we have at least owner and name
@@ -317,7 +343,7 @@ func (f *TrendingFetcher) SaveRaw(repos []models.RepoMetadata, date time.Time) e
 
 	// Format date as ISO date (YYYY-MM-DD)
 	dateStr := date.Format("2006-01-02")
-	dirPath := "data/trending_raw"
+	dirPath := RawDir
 	filename := fmt.Sprintf("%s/%s.json", dirPath, dateStr)
 
 	f.logger.Info().Str("filename", filename).Int("repos", len(repos)).Msg("Saving trending data")
@@ -3,6 +3,7 @@ package fetcher
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -108,7 +109,8 @@ func TestExtractRepoMetadata(t *testing.T) {
 		<p class="col-9">This is a test repository description</p>
 		<a class="topic-tag">golang</a>
 		<a class="topic-tag">testing</a>
-		<span class="d-inline-block float-sm-right">1,234</span>
+		<a href="/owner/repo/stargazers">1,234</a>
+		<span class="d-inline-block float-sm-right">56 stars today</span>
 	</article>
 	`
 
@@ -329,3 +331,72 @@ func TestSaveRawISODateFormat(t *testing.T) {
 		})
 	}
 }
+
+// TestLoadLatestRaw tests finding and loading the most recent snapshot
+func TestLoadLatestRaw(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"2024-02-07.json", "2024-02-14.json", "2024-01-31.json"} {
+		repos := []models.RepoMetadata{{Owner: "owner", Name: strings.TrimSuffix(name, ".json")}}
+		data, err := json.Marshal(repos)
+		if err != nil {
+			t.Fatalf("Failed to marshal snapshot: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatalf("Failed to write snapshot: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644); err != nil {
+		t.Fatalf("Failed to write notes: %v", err)
+	}
+
+	latest, err := LatestRaw(dir)
+	if err != nil {
+		t.Fatalf("LatestRaw failed: %v", err)
+	}
+	if filepath.Base(latest) != "2024-02-14.json" {
+		t.Errorf("Expected latest snapshot 2024-02-14.json, got %s", latest)
+	}
+
+	repos, err := LoadRaw(latest)
+	if err != nil {
+		t.Fatalf("LoadRaw failed: %v", err)
+	}
+	if len(repos) != 1 || repos[0].Name != "2024-02-14" {
+		t.Errorf("Unexpected snapshot content: %+v", repos)
+	}
+
+	if _, err := LatestRaw(t.TempDir()); err == nil {
+		t.Error("Expected error for directory without snapshots")
+	}
+}
+
+// TestLoadPriorRaw tests loading snapshots taken before a date
+func TestLoadPriorRaw(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"2024-01-01.json", "2024-02-07.json", "2024-02-13.json", "2024-02-14.json"} {
+		repos := []models.RepoMetadata{{Owner: "owner", Name: strings.TrimSuffix(name, ".json")}}
+		data, err := json.Marshal(repos)
+		if err != nil {
+			t.Fatalf("Failed to marshal snapshot: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatalf("Failed to write snapshot: %v", err)
+		}
+	}
+
+	snapshots, err := LoadPriorRaw(dir, time.Date(2024, 2, 14, 9, 0, 0, 0, time.UTC), 30)
+	if err != nil {
+		t.Fatalf("LoadPriorRaw failed: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 prior snapshots, got %d", len(snapshots))
+	}
+	if snapshots[0].Repos[0].Name != "2024-02-13" || snapshots[1].Repos[0].Name != "2024-02-07" {
+		t.Errorf("Expected newest snapshot first, got %s then %s", snapshots[0].Repos[0].Name, snapshots[1].Repos[0].Name)
+	}
+	if !snapshots[0].Date.Equal(time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected snapshot date %s", snapshots[0].Date)
+	}
+}
@@ -0,0 +1,117 @@
+package fetcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"ai-repo-insights/internal/errors"
+	"ai-repo-insights/internal/models"
+)
+
+// LoadRaw loads a trending snapshot written by SaveRaw
+func LoadRaw(path string) ([]models.RepoMetadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewFilesystemError("failed to read trending snapshot", path, err)
+	}
+
+	var repos []models.RepoMetadata
+	if err := json.Unmarshal(data, &repos); err != nil {
+		return nil, errors.NewFilesystemError("failed to parse trending snapshot", path, err)
+	}
+
+	return repos, nil
+}
+
+// ListRaw returns the snapshot files in dir, oldest first.
+// Snapshot names are ISO dates, so lexical order is chronological.
+func ListRaw(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.NewFilesystemError("failed to list trending snapshots", dir, err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// LatestRaw returns the path of the most recent snapshot in dir
+func LatestRaw(dir string) (string, error) {
+	paths, err := ListRaw(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(paths) == 0 {
+		return "", errors.NewFilesystemError(fmt.Sprintf("no trending snapshots found in %s", dir), dir, nil)
+	}
+
+	return paths[len(paths)-1], nil
+}
+
+// LoadPriorRaw loads the snapshots in dir taken within days before date, newest first.
+// The snapshot for date itself and files not named by ISO date are skipped.
+func LoadPriorRaw(dir string, date time.Time, days int) ([]models.TrendingSnapshot, error) {
+	paths, err := ListRaw(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	oldest := day.AddDate(0, 0, -days)
+
+	var snapshots []models.TrendingSnapshot
+	for i := len(paths) - 1; i >= 0; i-- {
+		taken, err := snapshotDate(paths[i])
+		if err != nil || !taken.Before(day) {
+			continue
+		}
+		if taken.Before(oldest) {
+			break
+		}
+
+		repos, err := LoadRaw(paths[i])
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, models.TrendingSnapshot{Date: taken, Repos: repos})
+	}
+
+	return snapshots, nil
+}
+
+// LoadRawSnapshots loads the given snapshot files, taking each date from its file name
+func LoadRawSnapshots(paths []string) ([]models.TrendingSnapshot, error) {
+	var snapshots []models.TrendingSnapshot
+	for _, path := range paths {
+		taken, err := snapshotDate(path)
+		if err != nil {
+			return nil, errors.NewFilesystemError("trending snapshot name is not an ISO date", path, err)
+		}
+
+		repos, err := LoadRaw(path)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, models.TrendingSnapshot{Date: taken, Repos: repos})
+	}
+
+	return snapshots, nil
+}
+
+// snapshotDate parses the ISO date SaveRaw uses as a snapshot's file name
+func snapshotDate(path string) (time.Time, error) {
+	return time.Parse("2006-01-02", strings.TrimSuffix(filepath.Base(path), ".json"))
+}
@@ -2,6 +2,7 @@ package history
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -58,7 +59,10 @@ func (m *Manager) LoadHistory() (*models.History, error) {
 	return &history, nil
 }
 
-// UpdateHistory updates history with current rankings
+// UpdateHistory updates history with current rankings. Updates are idempotent per
+// report ID: re-running the latest report replaces its contribution. Re-running an
+// earlier one returns history unchanged with an error, since later reports were built
+// on it; only a rebuild can recompute it.
 func (m *Manager) UpdateHistory(currentTop []models.ScoredRepo, reportID string, reportDate string) (*models.History, error) {
 	// Load existing history
 	history, err := m.LoadHistory()
@@ -66,22 +70,79 @@ func (m *Manager) UpdateHistory(currentTop []models.ScoredRepo, reportID string,
 		return nil, err
 	}
 
-	// Build set of current top repo keys
-	currentRepoKeys := make(map[string]bool)
-	for _, repo := range currentTop {
-		currentRepoKeys[repo.Key()] = true
+	switch {
+	case history.LatestReport == reportID && history.Previous != nil:
+		m.logger.Info().
+			Str("report_id", reportID).
+			Msg("re-running latest report, replacing its contribution to history")
+		reports := history.Reports
+		history = history.Previous
+		history.Reports = reports
+		if history.History == nil {
+			history.History = make(map[string]models.RepoHistory)
+		}
+	case IsEarlierReport(history, reportID):
+		return history, errors.NewConfigError(fmt.Sprintf(
+			"report %s was applied before the latest report %s, history left unchanged; use -force to recompute it",
+			reportID, history.LatestReport), nil)
 	}
 
-	// Update or initialize repos in current top
-	for _, repo := range currentTop {
-		repoKey := repo.Key()
-		
-		if existingHistory, exists := history.History[repoKey]; exists {
-			// Repo was in history, increment counter
-			existingHistory.WeeksInTop++
+	repos := make([]models.RepoMetadata, len(currentTop))
+	for i, repo := range currentTop {
+		repos[i] = repo.Repo.Metadata
+	}
+	m.apply(history, repos, reportID, reportDate)
+
+	return history, nil
+}
+
+// apply adds one report's top list to history, keeping the prior state in Previous
+func (m *Manager) apply(history *models.History, currentTop []models.RepoMetadata, reportID string, reportDate string) {
+	previous := &models.History{
+		LatestReport: history.LatestReport,
+		History:      make(map[string]models.RepoHistory, len(history.History)),
+	}
+	for key, entry := range history.History {
+		previous.History[key] = entry
+	}
+	indexAliases(previous)
+
+	// Build set of current top history keys while updating or initializing each repo.
+	// Entries are stored under the stable ID when known, so a renamed or transferred
+	// repository keeps its streak; its former owner/repo key is kept as an alias.
+	currentRepoKeys := make(map[string]bool)
+	for i := range currentTop {
+		metadata := &currentTop[i]
+		repoKey := metadata.Key()
+		historyKey := metadata.HistoryID()
+
+		if existingKey, exists := history.Find(metadata); exists {
+			existingHistory := history.History[existingKey]
+
+			// Without an ID this run, keep the entry where it is; with one, move
+			// entries still keyed by owner/repo under the ID
+			if metadata.ID == "" {
+				historyKey = existingKey
+			} else if existingKey != historyKey {
+				delete(history.History, existingKey)
+			}
+
+			if existingHistory.Key != "" && existingHistory.Key != repoKey {
+				existingHistory.Aliases = addAlias(existingHistory.Aliases, existingHistory.Key, repoKey)
+				m.logger.Info().
+					Str("from", existingHistory.Key).
+					Str("to", repoKey).
+					Msg("repository renamed or transferred, keeping its history")
+			}
+
+			// Repo was in history, increment counter once per report
+			if existingHistory.LastSeenReport != reportID {
+				existingHistory.WeeksInTop++
+			}
 			existingHistory.LastSeenReport = reportID
 			existingHistory.LastSeenDate = reportDate
-			history.History[repoKey] = existingHistory
+			existingHistory.Key = repoKey
+			history.History[historyKey] = existingHistory
 			
 			m.logger.Debug().
 				Str("repo", repoKey).
@@ -89,18 +150,20 @@ func (m *Manager) UpdateHistory(currentTop []models.ScoredRepo, reportID string,
 				Msg("updated existing repo in history")
 		} else {
 			// New repo, initialize
-			history.History[repoKey] = models.RepoHistory{
+			history.History[historyKey] = models.RepoHistory{
 				WeeksInTop:      1,
 				LastSeenReport:  reportID,
 				LastSeenDate:    reportDate,
 				FirstSeenReport: reportID,
 				FirstSeenDate:   reportDate,
+				Key:             repoKey,
 			}
 			
 			m.logger.Debug().
 				Str("repo", repoKey).
 				Msg("added new repo to history")
 		}
+		currentRepoKeys[historyKey] = true
 	}
 
 	// Remove repos not in current top (streak broken)
@@ -121,8 +184,14 @@ func (m *Manager) UpdateHistory(currentTop []models.ScoredRepo, reportID string,
 			Msg("removed repo from history (streak broken)")
 	}
 
+	indexAliases(history)
+
 	// Update latest report
 	history.LatestReport = reportID
+	history.Previous = previous
+	if !containsReport(history.Reports, reportID) {
+		history.Reports = append(history.Reports, reportID)
+	}
 
 	m.logger.Info().
 		Str("report_id", reportID).
@@ -130,8 +199,52 @@ func (m *Manager) UpdateHistory(currentTop []models.ScoredRepo, reportID string,
 		Int("tracked_repos", len(history.History)).
 		Int("removed_repos", len(reposToRemove)).
 		Msg("updated history")
+}
 
-	return history, nil
+// IsEarlierReport reports whether reportID was applied to history before its latest report
+func IsEarlierReport(history *models.History, reportID string) bool {
+	return history.LatestReport != reportID && containsReport(history.Reports, reportID)
+}
+
+// containsReport reports whether reportID is in reports
+func containsReport(reports []string, reportID string) bool {
+	for _, report := range reports {
+		if report == reportID {
+			return true
+		}
+	}
+	return false
+}
+
+// addAlias adds a former owner/repo key to aliases unless it is the current key
+func addAlias(aliases []string, alias string, current string) []string {
+	var kept []string
+	for _, existing := range aliases {
+		if existing != alias && existing != current {
+			kept = append(kept, existing)
+		}
+	}
+	if alias != current {
+		kept = append(kept, alias)
+	}
+	return kept
+}
+
+// indexAliases rebuilds the owner/repo key index of entries stored under a stable ID
+func indexAliases(history *models.History) {
+	history.Aliases = nil
+	for historyKey, entry := range history.History {
+		keys := append([]string{entry.Key}, entry.Aliases...)
+		for _, key := range keys {
+			if key == "" || key == historyKey {
+				continue
+			}
+			if history.Aliases == nil {
+				history.Aliases = make(map[string]string)
+			}
+			history.Aliases[key] = historyKey
+		}
+	}
 }
 
 // SaveHistory saves updated history to JSON file
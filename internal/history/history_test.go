@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -101,7 +102,7 @@ func TestUpdateHistory_NewRepo(t *testing.T) {
 					Name:  "repo1",
 				},
 			},
-			Heat90: 1000,
+			Heat30: 1000,
 		},
 	}
 
@@ -168,7 +169,7 @@ func TestUpdateHistory_ExistingRepo(t *testing.T) {
 					Name:  "repo1",
 				},
 			},
-			Heat90: 1000,
+			Heat30: 1000,
 		},
 	}
 
@@ -241,7 +242,7 @@ func TestUpdateHistory_RemoveRepoNotInTop(t *testing.T) {
 					Name:  "repo1",
 				},
 			},
-			Heat90: 1000,
+			Heat30: 1000,
 		},
 	}
 
@@ -288,7 +289,7 @@ func TestUpdateHistory_MultipleRepos(t *testing.T) {
 					Name:  "repo1",
 				},
 			},
-			Heat90: 1000,
+			Heat30: 1000,
 		},
 		{
 			Repo: models.ClassifiedRepo{
@@ -297,7 +298,7 @@ func TestUpdateHistory_MultipleRepos(t *testing.T) {
 					Name:  "repo2",
 				},
 			},
-			Heat90: 900,
+			Heat30: 900,
 		},
 		{
 			Repo: models.ClassifiedRepo{
@@ -306,7 +307,7 @@ func TestUpdateHistory_MultipleRepos(t *testing.T) {
 					Name:  "repo3",
 				},
 			},
-			Heat90: 800,
+			Heat30: 800,
 		},
 	}
 
@@ -491,7 +492,7 @@ func TestUpdateHistory_PreservesFirstSeen(t *testing.T) {
 					Name:  "repo1",
 				},
 			},
-			Heat90: 1000,
+			Heat30: 1000,
 		},
 	}
 
@@ -626,3 +627,236 @@ func TestSaveHistory_PreservesAllMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateHistory_RenamedRepo(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	historyPath := filepath.Join(tempDir, "history.json")
+	manager := NewManager(historyPath, zerolog.Nop())
+
+	topAs := func(owner string, name string, id string) []models.ScoredRepo {
+		return []models.ScoredRepo{{
+			Repo: models.ClassifiedRepo{
+				Metadata: models.RepoMetadata{Owner: owner, Name: name, ID: id},
+			},
+		}}
+	}
+
+	// A legacy entry keyed by owner/repo, then runs before and after a transfer
+	runs := []struct {
+		reportID string
+		top      []models.ScoredRepo
+	}{
+		{"2024-01-week2", topAs("alice", "tool", "R_1")},
+		{"2024-01-week3", topAs("acme", "tool", "R_1")},
+		// Without enrichment the new key is resolved through the alias index
+		{"2024-01-week4", topAs("acme", "tool", "")},
+	}
+
+	legacy := models.History{
+		LatestReport: "2024-01-week1",
+		History: map[string]models.RepoHistory{
+			"alice/tool": {WeeksInTop: 1, LastSeenReport: "2024-01-week1", FirstSeenReport: "2024-01-week1"},
+		},
+	}
+	data, _ := json.MarshalIndent(legacy, "", "  ")
+	os.WriteFile(historyPath, data, 0644)
+
+	for _, run := range runs {
+		history, err := manager.UpdateHistory(run.top, run.reportID, "")
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", run.reportID, err)
+		}
+		if err := manager.SaveHistory(history); err != nil {
+			t.Fatalf("%s: failed to save history: %v", run.reportID, err)
+		}
+	}
+
+	history, err := manager.LoadHistory()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(history.History) != 1 {
+		t.Fatalf("expected a single entry, got %v", history.History)
+	}
+	entry, exists := history.History["R_1"]
+	if !exists {
+		t.Fatalf("expected the entry to be keyed by ID, got %v", history.History)
+	}
+	if entry.WeeksInTop != 4 || entry.FirstSeenReport != "2024-01-week1" {
+		t.Errorf("expected a continuous 4-week streak from 2024-01-week1, got %+v", entry)
+	}
+	if entry.Key != "acme/tool" || len(entry.Aliases) != 1 || entry.Aliases[0] != "alice/tool" {
+		t.Errorf("expected key acme/tool with alias alice/tool, got %+v", entry)
+	}
+	if history.Aliases["alice/tool"] != "R_1" || history.Aliases["acme/tool"] != "R_1" {
+		t.Errorf("expected both keys to resolve to R_1, got %v", history.Aliases)
+	}
+
+	found, exists := history.Entry(&models.RepoMetadata{Owner: "alice", Name: "tool"})
+	if !exists || found.WeeksInTop != 4 {
+		t.Errorf("expected lookup by former key to find the entry, got %+v", found)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	history := &models.History{
+		History: map[string]models.RepoHistory{
+			"alice/tool": {WeeksInTop: 2, FirstSeenReport: "w1", FirstSeenDate: "2024-01-07", LastSeenReport: "w2", LastSeenDate: "2024-01-14"},
+			"acme/tool":  {WeeksInTop: 1, FirstSeenReport: "w3", FirstSeenDate: "2024-01-21", LastSeenReport: "w3", LastSeenDate: "2024-01-21"},
+			"other/repo": {WeeksInTop: 1},
+			"gone/repo":  {WeeksInTop: 3},
+		},
+	}
+	ids := map[string]string{
+		"alice/tool": "R_1",
+		"acme/tool":  "R_1",
+		"other/repo": "R_2",
+	}
+
+	if got := LegacyKeys(history); len(got) != 4 {
+		t.Fatalf("expected 4 legacy keys, got %v", got)
+	}
+
+	merged := Migrate(history, ids)
+
+	if merged != 1 {
+		t.Errorf("expected 1 merged entry, got %d", merged)
+	}
+	if len(history.History) != 3 {
+		t.Fatalf("expected R_1, R_2 and the unresolved gone/repo, got %v", history.History)
+	}
+
+	tool := history.History["R_1"]
+	if tool.WeeksInTop != 3 || tool.FirstSeenReport != "w1" || tool.LastSeenReport != "w3" {
+		t.Errorf("expected merged weeks and first/last seen, got %+v", tool)
+	}
+	if tool.Key != "acme/tool" || len(tool.Aliases) != 1 || tool.Aliases[0] != "alice/tool" {
+		t.Errorf("expected the latest key to stay current, got %+v", tool)
+	}
+	if history.History["R_2"].Key != "other/repo" {
+		t.Errorf("expected R_2 to keep its key, got %+v", history.History["R_2"])
+	}
+	if _, exists := history.History["gone/repo"]; !exists {
+		t.Error("expected unresolved entries to be left in place")
+	}
+	if got := LegacyKeys(history); len(got) != 1 || got[0] != "gone/repo" {
+		t.Errorf("expected only gone/repo to remain legacy, got %v", got)
+	}
+}
+
+func TestUpdateHistory_Rerun(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	historyPath := filepath.Join(tempDir, "history.json")
+	manager := NewManager(historyPath, zerolog.Nop())
+
+	top := func(names ...string) []models.ScoredRepo {
+		var repos []models.ScoredRepo
+		for _, name := range names {
+			repos = append(repos, models.ScoredRepo{
+				Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: name}},
+			})
+		}
+		return repos
+	}
+	run := func(repos []models.ScoredRepo, reportID string) *models.History {
+		t.Helper()
+		history, err := manager.UpdateHistory(repos, reportID, "")
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", reportID, err)
+		}
+		if err := manager.SaveHistory(history); err != nil {
+			t.Fatalf("%s: failed to save history: %v", reportID, err)
+		}
+		return history
+	}
+
+	run(top("steady", "dropped"), "week1")
+	run(top("steady"), "week2")
+
+	// Re-running week2 twice, the second time with a corrected top list, must not stack
+	run(top("steady"), "week2")
+	history := run(top("steady", "dropped"), "week2")
+
+	if got := history.History["o/steady"].WeeksInTop; got != 2 {
+		t.Errorf("expected steady weeks_in_top=2 after reruns, got %d", got)
+	}
+	if got := history.History["o/dropped"].WeeksInTop; got != 2 {
+		t.Errorf("expected the rerun to restore dropped's streak, got weeks_in_top=%d", got)
+	}
+	if len(history.Reports) != 2 || history.Reports[1] != "week2" {
+		t.Errorf("expected reports [week1 week2], got %v", history.Reports)
+	}
+
+	// Re-running an earlier report is refused and leaves history unchanged
+	run(top("steady"), "week3")
+	history, err := manager.UpdateHistory(top("other"), "week2", "")
+	if err == nil {
+		t.Error("expected an error when re-running an earlier report")
+	}
+	if history.LatestReport != "week3" || history.History["o/steady"].WeeksInTop != 3 {
+		t.Errorf("expected history unchanged by an earlier report, got %+v", history.History)
+	}
+	if _, exists := history.History["o/other"]; exists {
+		t.Error("expected an earlier report not to add repositories")
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	manager := NewManager(filepath.Join(t.TempDir(), "history.json"), zerolog.Nop())
+
+	repo := func(name string) models.RepoMetadata { return models.RepoMetadata{Owner: "o", Name: name} }
+	rankings := []Ranking{
+		{ReportID: "week3", Date: "2024-01-21", Repos: []models.RepoMetadata{repo("a"), repo("b")}},
+		{ReportID: "week1", Date: "2024-01-30", Repos: []models.RepoMetadata{repo("a")}},
+		{ReportID: "week2", Date: "2024-01-14", Repos: []models.RepoMetadata{repo("a"), repo("b")}},
+		{ReportID: "extra", Date: "2024-01-28", Repos: []models.RepoMetadata{repo("b")}},
+	}
+
+	// week1 was re-run later, so its date is newer, but it keeps its original position
+	SortRankings(rankings, []string{"week1", "week2", "week3"})
+	var order []string
+	for _, ranking := range rankings {
+		order = append(order, ranking.ReportID)
+	}
+	if strings.Join(order, ",") != "week1,week2,week3,extra" {
+		t.Fatalf("unexpected replay order %v", order)
+	}
+
+	history := manager.Rebuild(rankings)
+
+	if history.LatestReport != "extra" {
+		t.Errorf("expected latest report extra, got %s", history.LatestReport)
+	}
+	if got := history.History["o/b"].WeeksInTop; got != 3 {
+		t.Errorf("expected b weeks_in_top=3, got %d", got)
+	}
+	if _, exists := history.History["o/a"]; exists {
+		t.Error("expected a's streak to be broken by the last report")
+	}
+	if history.Previous == nil || history.Previous.LatestReport != "week3" {
+		t.Errorf("expected the state before the last report to be kept, got %+v", history.Previous)
+	}
+}
+
+func TestRebuild_PublishedStreaks(t *testing.T) {
+	manager := NewManager(filepath.Join(t.TempDir(), "history.json"), zerolog.Nop())
+
+	repo := func(name string) models.RepoMetadata { return models.RepoMetadata{Owner: "o", Name: name} }
+	rankings := []Ranking{
+		{ReportID: "week1", Date: "2024-01-07", Repos: []models.RepoMetadata{repo("a")}, Streaks: map[string]int{"o/a": 4}},
+		{ReportID: "week2", Date: "2024-01-14", Repos: []models.RepoMetadata{repo("a"), repo("b")}, Streaks: map[string]int{"o/a": 5, "o/b": 9}},
+	}
+
+	history := manager.Rebuild(rankings)
+
+	// a was already on a streak before the first replayed report; b only joined in week2
+	if got := history.History["o/a"].WeeksInTop; got != 5 {
+		t.Errorf("expected a weeks_in_top=5, got %d", got)
+	}
+	if got := history.History["o/b"].WeeksInTop; got != 1 {
+		t.Errorf("expected b weeks_in_top=1, got %d", got)
+	}
+}
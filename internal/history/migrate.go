@@ -0,0 +1,82 @@
+package history
+
+import (
+	"sort"
+	"strings"
+
+	"ai-repo-insights/internal/models"
+)
+
+// LegacyKeys returns the sorted keys of entries still stored under an owner/repo key
+// rather than a stable ID. Stable IDs never contain a slash.
+func LegacyKeys(history *models.History) []string {
+	var keys []string
+	for key := range history.History {
+		if strings.Contains(key, "/") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Migrate moves each legacy entry under the stable ID that ids gives for its owner/repo
+// key, merging entries that resolve to the same repository, and returns the number of
+// entries merged away. Keys missing from ids are left as they are.
+func Migrate(history *models.History, ids map[string]string) int {
+	merged := 0
+
+	for _, key := range LegacyKeys(history) {
+		id := ids[key]
+		if id == "" {
+			continue
+		}
+
+		entry := history.History[key]
+		if entry.Key == "" {
+			entry.Key = key
+		}
+		delete(history.History, key)
+
+		if existing, exists := history.History[id]; exists {
+			entry = mergeEntries(existing, entry)
+			merged++
+		}
+		history.History[id] = entry
+	}
+
+	indexAliases(history)
+
+	// Keep the state a rerun restores consistent with the migrated entries
+	if history.Previous != nil {
+		Migrate(history.Previous, ids)
+	}
+
+	return merged
+}
+
+// mergeEntries combines two entries of the same repository. A repository appears under
+// one key per report, so the weeks add up; the most recently seen key stays current and
+// the other becomes an alias.
+func mergeEntries(a models.RepoHistory, b models.RepoHistory) models.RepoHistory {
+	latest, other := a, b
+	if b.LastSeenDate > a.LastSeenDate {
+		latest, other = b, a
+	}
+
+	merged := latest
+	merged.WeeksInTop = a.WeeksInTop + b.WeeksInTop
+	if other.FirstSeenDate != "" && other.FirstSeenDate < latest.FirstSeenDate {
+		merged.FirstSeenReport = other.FirstSeenReport
+		merged.FirstSeenDate = other.FirstSeenDate
+	}
+
+	merged.Aliases = nil
+	for _, alias := range append(append([]string{other.Key}, other.Aliases...), latest.Aliases...) {
+		if alias != "" {
+			merged.Aliases = addAlias(merged.Aliases, alias, merged.Key)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,71 @@
+package history
+
+import (
+	"sort"
+	"strings"
+
+	"ai-repo-insights/internal/models"
+)
+
+// Ranking is the top list of one report, replayed when history is rebuilt
+type Ranking struct {
+	ReportID string
+	Date     string
+	Repos    []models.RepoMetadata
+	// Streaks holds published weeks in top by owner/repo key, for streaks that began
+	// before the first replayed report
+	Streaks map[string]int
+}
+
+// Rebuild recomputes history from scratch by applying rankings in order. A published
+// streak longer than the replayed one is taken only for repositories first seen in the
+// first ranking, since only those can have started before it.
+func (m *Manager) Rebuild(rankings []Ranking) *models.History {
+	history := models.NewHistory()
+	for _, ranking := range rankings {
+		m.apply(history, ranking.Repos, ranking.ReportID, ranking.Date)
+
+		for key, weeks := range ranking.Streaks {
+			owner, name, _ := strings.Cut(key, "/")
+			historyKey, exists := history.Find(&models.RepoMetadata{Owner: owner, Name: name})
+			if !exists {
+				continue
+			}
+			entry := history.History[historyKey]
+			if entry.FirstSeenReport == rankings[0].ReportID && weeks > entry.WeeksInTop {
+				entry.WeeksInTop = weeks
+				history.History[historyKey] = entry
+			}
+		}
+	}
+
+	m.logger.Info().
+		Int("reports", len(rankings)).
+		Int("tracked_repos", len(history.History)).
+		Msg("rebuilt history from stored rankings")
+
+	return history
+}
+
+// SortRankings orders rankings for replay: reports listed in order come first, in that
+// order, followed by the rest by date and report ID
+func SortRankings(rankings []Ranking, order []string) {
+	position := make(map[string]int, len(order))
+	for i, reportID := range order {
+		position[reportID] = i
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		pi, knownI := position[rankings[i].ReportID]
+		pj, knownJ := position[rankings[j].ReportID]
+		switch {
+		case knownI && knownJ:
+			return pi < pj
+		case knownI != knownJ:
+			return knownI
+		case rankings[i].Date != rankings[j].Date:
+			return rankings[i].Date < rankings[j].Date
+		}
+		return rankings[i].ReportID < rankings[j].ReportID
+	})
+}
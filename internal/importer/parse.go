@@ -0,0 +1,278 @@
+package importer
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/history"
+	"ai-repo-insights/internal/models"
+)
+
+var (
+	titlePattern = regexp.MustCompile(`^# (.+?) GitHub Trending Report - (.+)$`)
+	fieldPattern = regexp.MustCompile(`^\*\*([^*]+)\*\*: (.*)$`)
+	linkPattern  = regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\)`)
+)
+
+// Report is a generated Markdown report read back into summary form
+type Report struct {
+	ReportID string
+	// Summary holds the header metadata, top repositories and repeaters
+	Summary models.SummaryJSON
+}
+
+// Parse reads the header, the top repositories table and the consecutive appearances
+// table of a report written by the report generator. Tables are read by column name,
+// so reports from before a column was added parse too.
+func Parse(markdown string) (Report, error) {
+	var report Report
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	if len(lines) == 0 {
+		return report, fmt.Errorf("empty report")
+	}
+	title := titlePattern.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if title == nil {
+		return report, fmt.Errorf("missing report title")
+	}
+	report.Summary.Meta.FilterDomain = title[1]
+	report.ReportID = strings.TrimSpace(title[2])
+
+	sections := splitSections(lines[1:])
+	parseHeader(sections[""], &report.Summary.Meta)
+
+	for heading, body := range sections {
+		switch {
+		case strings.HasPrefix(heading, "Top ") && strings.HasSuffix(heading, " Repositories"):
+			topRepos, err := parseTopTable(body)
+			if err != nil {
+				return report, err
+			}
+			report.Summary.TopRepos = topRepos
+		case heading == "Consecutive Appearances":
+			repeaters, err := parseRepeaters(body)
+			if err != nil {
+				return report, err
+			}
+			report.Summary.Repeaters = repeaters
+		}
+	}
+
+	if report.Summary.TopRepos == nil {
+		return report, fmt.Errorf("missing top repositories table")
+	}
+
+	return report, nil
+}
+
+// splitSections groups lines under their "## " heading; lines before the first
+// heading are keyed by the empty string
+func splitSections(lines []string) map[string][]string {
+	sections := make(map[string][]string)
+	heading := ""
+	for _, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			heading = strings.TrimSpace(strings.TrimPrefix(line, "## "))
+			continue
+		}
+		sections[heading] = append(sections[heading], line)
+	}
+	return sections
+}
+
+// parseHeader reads the bold metadata fields under the title
+func parseHeader(lines []string, meta *models.MetaInfo) {
+	for _, line := range lines {
+		field := fieldPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if field == nil {
+			continue
+		}
+		value := strings.TrimSpace(field[2])
+		switch field[1] {
+		case "Report Date":
+			meta.RunDate = value
+		case "Analysis Window":
+			meta.WindowDays, _ = strconv.Atoi(strings.TrimSuffix(value, " days"))
+		case "Top N":
+			meta.TopN, _ = strconv.Atoi(value)
+		}
+	}
+}
+
+// table is the first Markdown table of a section, with cells keyed by column name
+type table []map[string]string
+
+// readTable reads the first table in lines
+func readTable(lines []string) table {
+	var header []string
+	var rows table
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			if header != nil {
+				break
+			}
+			continue
+		}
+
+		cells := splitRow(line)
+		switch {
+		case header == nil:
+			header = cells
+		case strings.Trim(strings.Join(cells, ""), "-: ") == "":
+			// Separator row
+		default:
+			row := make(map[string]string, len(header))
+			for i, name := range header {
+				if i < len(cells) {
+					row[name] = cells[i]
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// splitRow splits a table row into trimmed cells
+func splitRow(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(line, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// parseTopTable reads the top repositories table
+func parseTopTable(lines []string) ([]models.TopRepoInfo, error) {
+	rows := readTable(lines)
+	topRepos := make([]models.TopRepoInfo, 0, len(rows))
+
+	for _, row := range rows {
+		key, name, link, err := parseRepoCell(row["Repository"])
+		if err != nil {
+			return nil, fmt.Errorf("top repositories table: %w", err)
+		}
+		rank, err := strconv.Atoi(row["Rank"])
+		if err != nil {
+			return nil, fmt.Errorf("top repositories table: invalid rank %q for %s", row["Rank"], key)
+		}
+
+		repo := models.TopRepoInfo{
+			Rank:     rank,
+			RepoKey:  key,
+			RepoName: name,
+			URL:      link,
+			Category: category(row["Category"]),
+			Language: row["Language"],
+			Heat7:    parseNumber(row["Heat_7"]),
+			Heat30:   parseNumber(row["Heat_30"]),
+			Score:    parseNumber(row["Score"]),
+		}
+		if display := linkText(row["Repository"]); display != name {
+			repo.DisplayName = display
+		}
+		if stage := row["Stage"]; stage != "-" {
+			repo.Lifecycle = stage
+		}
+		topRepos = append(topRepos, repo)
+	}
+
+	return topRepos, nil
+}
+
+// parseRepeaters reads the consecutive appearances table
+func parseRepeaters(lines []string) ([]models.RepeaterInfo, error) {
+	rows := readTable(lines)
+	repeaters := make([]models.RepeaterInfo, 0, len(rows))
+
+	for _, row := range rows {
+		key, name, link, err := parseRepoCell(row["Repository"])
+		if err != nil {
+			return nil, fmt.Errorf("consecutive appearances table: %w", err)
+		}
+		repeaters = append(repeaters, models.RepeaterInfo{
+			RepoKey:      key,
+			RepoName:     name,
+			URL:          link,
+			WeeksInTop:   parseNumber(row["Weeks in Top"]),
+			CurrentHeat7: parseNumber(row["Current Heat_7"]),
+			Category:     category(row["Category"]),
+		})
+	}
+
+	return repeaters, nil
+}
+
+// parseRepoCell reads the owner/repo key, repository name and URL of a linked repository.
+// Link text may be a curator display name, so the key comes from the GitHub URL.
+func parseRepoCell(cell string) (key string, name string, link string, err error) {
+	match := linkPattern.FindStringSubmatch(cell)
+	if match == nil {
+		return "", "", "", fmt.Errorf("no repository link in %q", cell)
+	}
+	link = match[2]
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid repository URL %q", link)
+	}
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("repository URL %q is not owner/repo", link)
+	}
+
+	return parts[0] + "/" + parts[1], parts[1], link, nil
+}
+
+// linkText returns the text of the first link in a cell
+func linkText(cell string) string {
+	if match := linkPattern.FindStringSubmatch(cell); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// category maps the blank category of older reports to the Other bucket
+func category(cell string) string {
+	if cell == "" {
+		return config.OtherCategory
+	}
+	return cell
+}
+
+// parseNumber reads a formatted number such as "12,345" or "1,234 ~", ignoring markers
+func parseNumber(cell string) int {
+	fields := strings.Fields(cell)
+	if len(fields) == 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.ReplaceAll(fields[0], ",", ""))
+	return n
+}
+
+// Ranking returns the report's top list for history replay, with the published streaks
+// of its repeaters
+func (r Report) Ranking() history.Ranking {
+	ranking := history.Ranking{
+		ReportID: r.ReportID,
+		Date:     r.Summary.Meta.RunDate,
+		Repos:    make([]models.RepoMetadata, 0, len(r.Summary.TopRepos)),
+		Streaks:  make(map[string]int, len(r.Summary.Repeaters)),
+	}
+	for _, repo := range r.Summary.TopRepos {
+		owner, name, _ := strings.Cut(repo.RepoKey, "/")
+		ranking.Repos = append(ranking.Repos, models.RepoMetadata{Owner: owner, Name: name, ID: repo.RepoID})
+	}
+	for _, repeater := range r.Summary.Repeaters {
+		ranking.Streaks[repeater.RepoKey] = repeater.WeeksInTop
+	}
+	return ranking
+}
@@ -0,0 +1,136 @@
+package importer
+
+import (
+	"testing"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/lifecycle"
+)
+
+// legacyReport uses the top table layout from before the Stage column was added
+const legacyReport = `# AI GitHub Trending Report - 2026-02-week6
+
+**Report Date**: 2026-02-07
+**Analysis Window**: 30 days
+**Languages Tracked**: python, typescript
+**Top N**: 50
+
+## Top 50 Repositories
+
+| Rank | Repository | Category | Language | Heat_7 | Heat_30 | Score |
+|------|-----------|----------|----------|---------|---------|-------|
+| 1 | [openclaw](https://github.com/openclaw/openclaw) |  | typescript | 58,305 | 168,498 | 3,060 |
+| 2 | [opencode](https://github.com/anomalyco/opencode) | agent | typescript | 0 | 48,566 | 161 |
+
+## Consecutive Appearances
+
+Both repositories returned from last week.
+
+| Repository | Weeks in Top | Category | Current Heat_7 |
+|-----------|--------------|----------|----------------|
+| [openclaw](https://github.com/openclaw/openclaw) | 2 |  | 58,305 |
+| [opencode](https://github.com/anomalyco/opencode) | 2 | agent | 0 |
+`
+
+// currentReport uses the Stage column, a curator display name and score and anomaly markers
+const currentReport = `# AI GitHub Trending Report - 2026-07-week30
+
+**Report Date**: 2026-07-20
+**Analysis Window**: 30 days
+**Top N**: 2
+
+## Top 2 Repositories
+
+| Rank | Repository | Category | Language | Stage | Heat_7 | Heat_30 | Score |
+|------|-----------|----------|----------|-------|---------|---------|-------|
+| 1 | [OpenMontage Studio](https://github.com/calesthio/OpenMontage) ⚠ | agent | python | accelerating | 0 | 34,396 | 114 ~ |
+| 2 | [orca](https://github.com/stablyai/orca) | agent | typescript | - | 5,652 | 16,894 | 298 |
+`
+
+func TestParse(t *testing.T) {
+	t.Run("report without Stage column", func(t *testing.T) {
+		report, err := Parse(legacyReport)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+
+		if report.ReportID != "2026-02-week6" {
+			t.Errorf("ReportID = %q, want 2026-02-week6", report.ReportID)
+		}
+		meta := report.Summary.Meta
+		if meta.RunDate != "2026-02-07" || meta.WindowDays != 30 || meta.TopN != 50 || meta.FilterDomain != "AI" {
+			t.Errorf("Meta = %+v", meta)
+		}
+
+		if len(report.Summary.TopRepos) != 2 {
+			t.Fatalf("TopRepos = %d, want 2", len(report.Summary.TopRepos))
+		}
+		first := report.Summary.TopRepos[0]
+		if first.RepoKey != "openclaw/openclaw" || first.Heat7 != 58305 || first.Heat30 != 168498 || first.Score != 3060 {
+			t.Errorf("TopRepos[0] = %+v", first)
+		}
+		if first.Category != config.OtherCategory {
+			t.Errorf("blank category = %q, want %q", first.Category, config.OtherCategory)
+		}
+
+		if len(report.Summary.Repeaters) != 2 || report.Summary.Repeaters[1].WeeksInTop != 2 {
+			t.Errorf("Repeaters = %+v", report.Summary.Repeaters)
+		}
+	})
+
+	t.Run("report with Stage column and markers", func(t *testing.T) {
+		report, err := Parse(currentReport)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+
+		first := report.Summary.TopRepos[0]
+		if first.RepoKey != "calesthio/OpenMontage" || first.RepoName != "OpenMontage" {
+			t.Errorf("key = %q name = %q, want key and name from the URL", first.RepoKey, first.RepoName)
+		}
+		if first.DisplayName != "OpenMontage Studio" {
+			t.Errorf("DisplayName = %q, want OpenMontage Studio", first.DisplayName)
+		}
+		if first.Lifecycle != lifecycle.Accelerating || first.Score != 114 {
+			t.Errorf("Lifecycle = %q Score = %d", first.Lifecycle, first.Score)
+		}
+		if second := report.Summary.TopRepos[1]; second.Lifecycle != "" || second.DisplayName != "" {
+			t.Errorf("TopRepos[1] = %+v, want no stage or display name", second)
+		}
+		if report.Summary.Repeaters != nil {
+			t.Errorf("Repeaters = %+v, want none", report.Summary.Repeaters)
+		}
+	})
+
+	t.Run("invalid reports", func(t *testing.T) {
+		invalid := map[string]string{
+			"missing title":     "**Report Date**: 2026-02-07\n",
+			"missing top table": "# AI GitHub Trending Report - 2026-02-week6\n\n## Overview\n",
+			"unlinked repository": "# AI GitHub Trending Report - 2026-02-week6\n\n## Top 1 Repositories\n\n" +
+				"| Rank | Repository |\n|---|---|\n| 1 | openclaw |\n",
+		}
+		for name, markdown := range invalid {
+			if _, err := Parse(markdown); err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+	})
+}
+
+func TestRanking(t *testing.T) {
+	report, err := Parse(legacyReport)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	ranking := report.Ranking()
+	if ranking.ReportID != "2026-02-week6" || ranking.Date != "2026-02-07" {
+		t.Errorf("ranking = %s on %s", ranking.ReportID, ranking.Date)
+	}
+	if len(ranking.Repos) != 2 || ranking.Repos[1].Owner != "anomalyco" || ranking.Repos[1].Name != "opencode" {
+		t.Errorf("Repos = %+v", ranking.Repos)
+	}
+	if ranking.Streaks["openclaw/openclaw"] != 2 {
+		t.Errorf("Streaks = %v, want openclaw/openclaw at 2", ranking.Streaks)
+	}
+}
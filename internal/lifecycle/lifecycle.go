@@ -0,0 +1,148 @@
+package lifecycle
+
+import (
+	"time"
+
+	"ai-repo-insights/internal/models"
+)
+
+// Lifecycle stages
+const (
+	// Emerging repositories are new to the top list
+	Emerging = "emerging"
+	// Accelerating repositories gain stars faster than before
+	Accelerating = "accelerating"
+	// Peaking repositories are at a plateau, or grew over the week but are slowing today
+	Peaking = "peaking"
+	// Cooling repositories gain stars more slowly than before
+	Cooling = "cooling"
+	// Evergreen repositories hold a steady pace over a long top-list streak
+	Evergreen = "evergreen"
+)
+
+// Stages lists every stage in lifecycle order
+var Stages = []string{Emerging, Accelerating, Peaking, Cooling, Evergreen}
+
+const (
+	// growthThreshold is the week-over-week change that counts as growing or declining
+	growthThreshold = 0.25
+	// fastDayRatio and slowDayRatio bound stars today against the weekly daily average
+	fastDayRatio = 1.5
+	slowDayRatio = 0.5
+	// evergreenStreak is the top-list streak, in reports, of an evergreen repository
+	evergreenStreak = 4
+	// Previous-week snapshots are looked for between these ages in days
+	minPreviousAge = 6
+	maxPreviousAge = 10
+)
+
+// Signals are the measurements a stage is derived from
+type Signals struct {
+	// DailyToWeekly is stars today divided by the weekly daily average, valid when
+	// HasDaily is set
+	DailyToWeekly float64
+	HasDaily      bool
+	// WeekOverWeek is the relative change of weekly stars since the previous week,
+	// valid when HasPrevious is set
+	WeekOverWeek float64
+	HasPrevious  bool
+	// Streak is the number of consecutive reports with the repository in the top list
+	Streak int
+}
+
+// Classify derives a stage from signals. A repository is growing when its weekly
+// stars rose by growthThreshold or today's pace is well above the weekly average,
+// and declining in the opposite cases. Growing and declining at once is a peak.
+// Without stars today only the week-over-week change and the streak count.
+func Classify(s Signals) string {
+	growing := (s.HasPrevious && s.WeekOverWeek >= growthThreshold) || (s.HasDaily && s.DailyToWeekly >= fastDayRatio)
+	declining := (s.HasPrevious && s.WeekOverWeek <= -growthThreshold) || (s.HasDaily && s.DailyToWeekly < slowDayRatio)
+
+	switch {
+	case growing && declining:
+		return Peaking
+	case declining:
+		return Cooling
+	case s.Streak >= evergreenStreak && !growing:
+		return Evergreen
+	case s.Streak <= 1:
+		return Emerging
+	case growing:
+		return Accelerating
+	default:
+		return Peaking
+	}
+}
+
+// Labeler assigns stages using weekly stars from the previous week's snapshot
+type Labeler struct {
+	previousWeek map[string]int
+}
+
+// NewLabeler indexes the weekly stars of each repository in the newest snapshot taken
+// 6 to 10 days before asOf that listed it on the weekly page. Snapshots must be ordered
+// newest first.
+func NewLabeler(asOf time.Time, snapshots []models.TrendingSnapshot) *Labeler {
+	day := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	labeler := &Labeler{previousWeek: make(map[string]int)}
+
+	for _, snapshot := range snapshots {
+		age := int(day.Sub(snapshot.Date).Hours() / 24)
+		if age < minPreviousAge || age > maxPreviousAge {
+			continue
+		}
+		for _, repo := range snapshot.Repos {
+			if !repo.OnWindow(models.WindowWeekly) {
+				continue
+			}
+			for _, key := range repo.MatchKeys() {
+				if _, exists := labeler.previousWeek[key]; !exists {
+					labeler.previousWeek[key] = repo.StarsThisWeek
+				}
+			}
+		}
+	}
+
+	return labeler
+}
+
+// Label sets the lifecycle stage of each repository; history supplies top-list streaks
+func (l *Labeler) Label(repos []models.ScoredRepo, history *models.History) {
+	for i := range repos {
+		repos[i].Lifecycle = Classify(l.signals(repos[i], history))
+	}
+}
+
+// signals measures the lifecycle signals of one repository
+func (l *Labeler) signals(repo models.ScoredRepo, history *models.History) Signals {
+	var s Signals
+
+	// A repository missing from the daily list has no stars today to compare
+	if repo.Metrics.Today != models.MetricMissing {
+		s.HasDaily = true
+		weeklyAverage := float64(repo.Heat7) / 7
+		switch {
+		case weeklyAverage > 0:
+			s.DailyToWeekly = float64(repo.StarsToday) / weeklyAverage
+		case repo.StarsToday > 0:
+			s.DailyToWeekly = fastDayRatio
+		}
+	}
+
+	for _, key := range repo.Repo.Metadata.MatchKeys() {
+		if previous, exists := l.previousWeek[key]; exists {
+			if previous > 0 {
+				s.HasPrevious = true
+				s.WeekOverWeek = float64(repo.Heat7-previous) / float64(previous)
+			}
+			break
+		}
+	}
+
+	if history != nil {
+		entry, _ := history.Entry(&repo.Repo.Metadata)
+		s.Streak = entry.WeeksInTop
+	}
+
+	return s
+}
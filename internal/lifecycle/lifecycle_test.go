@@ -0,0 +1,80 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"ai-repo-insights/internal/models"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name     string
+		signals  Signals
+		expected string
+	}{
+		{"new to the top list", Signals{HasDaily: true, DailyToWeekly: 1, Streak: 1}, Emerging},
+		{"new and cooling", Signals{HasDaily: true, DailyToWeekly: 0.3, Streak: 1}, Cooling},
+		{"week-over-week growth", Signals{HasDaily: true, DailyToWeekly: 1, WeekOverWeek: 0.5, HasPrevious: true, Streak: 2}, Accelerating},
+		{"fast day", Signals{HasDaily: true, DailyToWeekly: 2, Streak: 3}, Accelerating},
+		{"plateau", Signals{HasDaily: true, DailyToWeekly: 1, WeekOverWeek: 0.1, HasPrevious: true, Streak: 2}, Peaking},
+		{"grew but slowing today", Signals{HasDaily: true, DailyToWeekly: 0.4, WeekOverWeek: 0.6, HasPrevious: true, Streak: 2}, Peaking},
+		{"week-over-week decline", Signals{HasDaily: true, DailyToWeekly: 0.9, WeekOverWeek: -0.4, HasPrevious: true, Streak: 6}, Cooling},
+		{"long steady streak", Signals{HasDaily: true, DailyToWeekly: 1.1, WeekOverWeek: -0.1, HasPrevious: true, Streak: 5}, Evergreen},
+		{"long streak still accelerating", Signals{HasDaily: true, DailyToWeekly: 1, WeekOverWeek: 0.4, HasPrevious: true, Streak: 5}, Accelerating},
+		{"no stars today, new", Signals{Streak: 1}, Emerging},
+		{"no stars today, weekly growth", Signals{WeekOverWeek: 0.5, HasPrevious: true, Streak: 2}, Accelerating},
+		{"no stars today, steady", Signals{WeekOverWeek: 0.1, HasPrevious: true, Streak: 2}, Peaking},
+		{"no stars today, long streak", Signals{Streak: 5}, Evergreen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.signals); got != tt.expected {
+				t.Errorf("Classify(%+v) = %s, want %s", tt.signals, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLabeler(t *testing.T) {
+	asOf := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	weekly := []string{models.WindowWeekly}
+	snapshots := []models.TrendingSnapshot{
+		// Too recent to be the previous week
+		{Date: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), Repos: []models.RepoMetadata{
+			{Owner: "o", Name: "riser", StarsThisWeek: 9000, TrendingWindows: weekly},
+		}},
+		{Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Repos: []models.RepoMetadata{
+			{Owner: "o", Name: "riser", StarsThisWeek: 400, TrendingWindows: weekly},
+			{Owner: "o", Name: "fader", StarsThisWeek: 2000, TrendingWindows: weekly},
+		}},
+	}
+	history := &models.History{History: map[string]models.RepoHistory{
+		"o/riser": {WeeksInTop: 2},
+		"o/fader": {WeeksInTop: 3},
+	}}
+
+	repos := []models.ScoredRepo{
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: "riser"}}, Heat7: 700, StarsToday: 100},
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: "fader"}}, Heat7: 700, StarsToday: 100},
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: "fresh"}}, Heat7: 700, StarsToday: 100},
+		// Only on the weekly list: zero stars today must not read as cooling
+		{
+			Repo:    models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: "weekly-only"}},
+			Heat7:   700,
+			Metrics: models.MetricStatus{Today: models.MetricMissing, Week: models.MetricObserved},
+		},
+	}
+
+	NewLabeler(asOf, snapshots).Label(repos, history)
+
+	// riser went from 400 to 700 weekly stars, fader from 2000 to 700, fresh and
+	// weekly-only have no streak
+	expected := []string{Accelerating, Cooling, Emerging, Emerging}
+	for i, repo := range repos {
+		if repo.Lifecycle != expected[i] {
+			t.Errorf("%s: lifecycle = %s, want %s", repo.Key(), repo.Lifecycle, expected[i])
+		}
+	}
+}
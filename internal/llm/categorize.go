@@ -0,0 +1,271 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/config"
+	apperrors "ai-repo-insights/internal/errors"
+	"ai-repo-insights/internal/models"
+)
+
+const (
+	// DefaultCategoryCachePath stores LLM category decisions keyed by lowercase owner/repo
+	DefaultCategoryCachePath = "data/cache/llm_categories.json"
+
+	// noCategory is the answer the LLM gives when no configured category fits
+	noCategory = "other"
+
+	categorizeBatchSize = 20
+)
+
+// Categorizer asks the LLM to place repositories that matched no category
+// into one of the configured categories, caching each decision per repository
+type Categorizer struct {
+	client        *Client
+	keywords      config.KeywordConfig
+	minConfidence float64
+	cachePath     string
+	logger        zerolog.Logger
+}
+
+// NewCategorizer creates a categorizer for the configured categories
+func NewCategorizer(client *Client, keywords config.KeywordConfig, minConfidence float64, logger zerolog.Logger) *Categorizer {
+	return &Categorizer{
+		client:        client,
+		keywords:      keywords,
+		minConfidence: minConfidence,
+		cachePath:     DefaultCategoryCachePath,
+		logger:        logger,
+	}
+}
+
+// categoryRequest is one repository as presented to the LLM
+type categoryRequest struct {
+	Repo        string   `json:"repo"`
+	Description string   `json:"description"`
+	Topics      []string `json:"topics,omitempty"`
+	Language    string   `json:"language,omitempty"`
+}
+
+// Categorize fills in the primary category of repositories that have none.
+// Decisions below the minimum confidence, or "other", leave the repository
+// uncategorized. It returns the number of repositories that gained a category;
+// on error, decisions from batches that succeeded are still applied.
+func (c *Categorizer) Categorize(repos []models.ClassifiedRepo) (int, error) {
+	cache := c.loadCache()
+
+	var pending []int
+	for i, repo := range repos {
+		if repo.PrimaryCategory != "" {
+			continue
+		}
+		if _, cached := cache[strings.ToLower(repo.Key())]; !cached {
+			pending = append(pending, i)
+		}
+	}
+
+	c.logger.Debug().Int("uncached", len(pending)).Msg("categorizing uncategorized repositories")
+
+	var firstErr error
+	for start := 0; start < len(pending); start += categorizeBatchSize {
+		end := start + categorizeBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		batch := make([]models.ClassifiedRepo, 0, end-start)
+		for _, index := range pending[start:end] {
+			batch = append(batch, repos[index])
+		}
+
+		decisions, err := c.categorizeBatch(batch)
+		if err != nil {
+			c.logger.Warn().Err(err).Int("batch_size", len(batch)).Msg("LLM categorization batch failed")
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for key, decision := range decisions {
+			cache[key] = decision
+		}
+	}
+
+	if len(pending) > 0 {
+		if err := c.saveCache(cache); err != nil {
+			c.logger.Warn().Err(err).Msg("failed to save LLM category cache")
+		}
+	}
+
+	assigned := 0
+	for i := range repos {
+		if repos[i].PrimaryCategory != "" {
+			continue
+		}
+		decision, exists := cache[strings.ToLower(repos[i].Key())]
+		if !exists {
+			continue
+		}
+
+		repos[i].LLMCategory = &decision
+		if decision.Category != noCategory && decision.Confidence >= c.minConfidence {
+			repos[i].PrimaryCategory = decision.Category
+			repos[i].Categories = []string{decision.Category}
+			assigned++
+		}
+	}
+
+	return assigned, firstErr
+}
+
+// categorizeBatch asks the LLM for one batch and returns decisions keyed by lowercase owner/repo
+func (c *Categorizer) categorizeBatch(batch []models.ClassifiedRepo) (map[string]models.CategoryDecision, error) {
+	responseText, err := c.client.callAPIWithRetry(c.buildPrompt(batch))
+	if err != nil {
+		return nil, apperrors.NewLLMError("failed to call LLM API after retries", err)
+	}
+
+	decisions, err := c.parseDecisions(responseText, batch)
+	if err != nil {
+		return nil, apperrors.NewLLMError("failed to parse LLM categorization", err)
+	}
+
+	return decisions, nil
+}
+
+// buildPrompt lists the configured categories and the repositories to place
+func (c *Categorizer) buildPrompt(batch []models.ClassifiedRepo) string {
+	var categories strings.Builder
+	for _, name := range c.keywords.OrderedCategories() {
+		categories.WriteString(fmt.Sprintf("- %s: %s\n", name, strings.Join(c.keywords.Categories[name], ", ")))
+	}
+
+	requests := make([]categoryRequest, 0, len(batch))
+	for _, repo := range batch {
+		requests = append(requests, categoryRequest{
+			Repo:        repo.Key(),
+			Description: repo.Metadata.Description,
+			Topics:      repo.Metadata.Topics,
+			Language:    repo.Metadata.Language,
+		})
+	}
+	reposJSON, _ := json.MarshalIndent(requests, "", "  ")
+
+	return fmt.Sprintf(`Role: %s
+
+Task: Assign each GitHub repository below to exactly one category.
+
+Categories (name: example keywords):
+%s
+Repositories:
+%s
+
+Instructions:
+1. Use only the category names listed above, or "%s" if none fits
+2. Give a confidence between 0 and 1 for each assignment
+3. Include every repository exactly once
+4. Output valid JSON in this structure:
+{
+  "assignments": [
+    {"repo": "owner/repo", "category": "...", "confidence": 0.8}
+  ]
+}`,
+		c.client.config.RoleDescription,
+		categories.String(),
+		string(reposJSON),
+		noCategory,
+	)
+}
+
+// parseDecisions parses the LLM answer, mapping unknown categories to "other"
+// and ignoring repositories that were not asked about
+func (c *Categorizer) parseDecisions(responseText string, batch []models.ClassifiedRepo) (map[string]models.CategoryDecision, error) {
+	var response struct {
+		Assignments []struct {
+			Repo       string  `json:"repo"`
+			Category   string  `json:"category"`
+			Confidence float64 `json:"confidence"`
+		} `json:"assignments"`
+	}
+	if err := json.Unmarshal([]byte(cleanJSONResponse(responseText)), &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON response: %w", err)
+	}
+
+	requested := make(map[string]bool, len(batch))
+	for _, repo := range batch {
+		requested[strings.ToLower(repo.Key())] = true
+	}
+
+	decisions := make(map[string]models.CategoryDecision, len(response.Assignments))
+	for _, assignment := range response.Assignments {
+		key := strings.ToLower(strings.TrimSpace(assignment.Repo))
+		if !requested[key] {
+			continue
+		}
+
+		category := c.knownCategory(assignment.Category)
+		confidence := assignment.Confidence
+		if confidence < 0 {
+			confidence = 0
+		} else if confidence > 1 {
+			confidence = 1
+		}
+		decisions[key] = models.CategoryDecision{Category: category, Confidence: confidence}
+	}
+
+	return decisions, nil
+}
+
+// knownCategory returns the configured category matching name, ignoring case, or "other"
+func (c *Categorizer) knownCategory(name string) string {
+	name = strings.TrimSpace(name)
+	for category := range c.keywords.Categories {
+		if strings.EqualFold(category, name) {
+			return category
+		}
+	}
+	return noCategory
+}
+
+// loadCache reads cached decisions, dropping those whose category no longer exists
+func (c *Categorizer) loadCache() map[string]models.CategoryDecision {
+	cache := make(map[string]models.CategoryDecision)
+
+	data, err := os.ReadFile(c.cachePath)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		c.logger.Warn().Err(err).Str("path", c.cachePath).Msg("ignoring unreadable LLM category cache")
+		return make(map[string]models.CategoryDecision)
+	}
+
+	for key, decision := range cache {
+		if decision.Category != noCategory {
+			if _, exists := c.keywords.Categories[decision.Category]; !exists {
+				delete(cache, key)
+			}
+		}
+	}
+	return cache
+}
+
+// saveCache writes all decisions to the cache file
+func (c *Categorizer) saveCache(cache map[string]models.CategoryDecision) error {
+	if err := os.MkdirAll(filepath.Dir(c.cachePath), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache: %w", err)
+	}
+
+	return os.WriteFile(c.cachePath, data, 0644)
+}
@@ -0,0 +1,140 @@
+package llm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+const categorizeAnswer = "```json\n" + `{
+  "assignments": [
+    {"repo": "acme/planner", "category": "Agent", "confidence": 0.9},
+    {"repo": "acme/notes", "category": "rag", "confidence": 0.3},
+    {"repo": "acme/game", "category": "gaming", "confidence": 0.8},
+    {"repo": "someone/else", "category": "agent", "confidence": 1}
+  ]
+}` + "\n```"
+
+func newTestCategorizer(t *testing.T, requests *int32) (*Categorizer, func()) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"message": map[string]string{"content": categorizeAnswer}},
+			},
+		})
+	}))
+
+	client := NewClient(config.LLMConfig{
+		BaseURL:         server.URL,
+		Model:           "test-model",
+		Provider:        "openai",
+		TimeoutSeconds:  5,
+		RoleDescription: "analyst",
+	}, "test-key", zerolog.Nop())
+	keywords := config.KeywordConfig{
+		Categories: map[string][]string{
+			"agent": {"agent"},
+			"rag":   {"rag"},
+		},
+	}
+
+	categorizer := NewCategorizer(client, keywords, 0.5, zerolog.Nop())
+	categorizer.cachePath = filepath.Join(t.TempDir(), "llm_categories.json")
+	return categorizer, server.Close
+}
+
+func uncategorizedRepos() []models.ClassifiedRepo {
+	return []models.ClassifiedRepo{
+		{Metadata: models.RepoMetadata{Owner: "acme", Name: "planner"}},
+		{Metadata: models.RepoMetadata{Owner: "acme", Name: "notes"}},
+		{Metadata: models.RepoMetadata{Owner: "Acme", Name: "Game"}},
+		{Metadata: models.RepoMetadata{Owner: "acme", Name: "agent-kit"}, PrimaryCategory: "agent", Categories: []string{"agent"}},
+	}
+}
+
+func TestCategorizer_Categorize(t *testing.T) {
+	var requests int32
+	categorizer, closeServer := newTestCategorizer(t, &requests)
+	defer closeServer()
+
+	repos := uncategorizedRepos()
+	assigned, err := categorizer.Categorize(repos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if assigned != 1 {
+		t.Errorf("expected 1 assignment, got %d", assigned)
+	}
+
+	if repos[0].PrimaryCategory != "agent" || len(repos[0].Categories) != 1 {
+		t.Errorf("expected acme/planner in agent, got %q %v", repos[0].PrimaryCategory, repos[0].Categories)
+	}
+	if repos[1].PrimaryCategory != "" || repos[1].LLMCategory == nil || repos[1].LLMCategory.Confidence != 0.3 {
+		t.Errorf("expected low-confidence decision to be recorded but not applied, got %+v", repos[1])
+	}
+	if repos[2].PrimaryCategory != "" || repos[2].LLMCategory == nil || repos[2].LLMCategory.Category != "other" {
+		t.Errorf("expected unknown category to become other, got %+v", repos[2])
+	}
+	if repos[3].LLMCategory != nil {
+		t.Error("expected already categorized repo to be left alone")
+	}
+
+	// A second run is answered from the cache
+	repos = uncategorizedRepos()
+	if _, err := categorizer.Categorize(repos); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected cached decisions to be reused, got %d requests", requests)
+	}
+	if repos[0].PrimaryCategory != "agent" {
+		t.Errorf("expected cached decision to be applied, got %q", repos[0].PrimaryCategory)
+	}
+}
+
+func TestCategorizer_DropsStaleCacheEntries(t *testing.T) {
+	var requests int32
+	categorizer, closeServer := newTestCategorizer(t, &requests)
+	defer closeServer()
+
+	if _, err := categorizer.Categorize(uncategorizedRepos()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Removing the agent category invalidates the cached acme/planner decision
+	delete(categorizer.keywords.Categories, "agent")
+	cache := categorizer.loadCache()
+	if _, exists := cache["acme/planner"]; exists {
+		t.Error("expected decision for a removed category to be dropped")
+	}
+	if decision := cache["acme/game"]; decision.Category != "other" {
+		t.Errorf("expected other decision to be kept, got %+v", decision)
+	}
+}
+
+func TestCategorizer_BuildPrompt(t *testing.T) {
+	var requests int32
+	categorizer, closeServer := newTestCategorizer(t, &requests)
+	defer closeServer()
+
+	prompt := categorizer.buildPrompt(uncategorizedRepos()[:1])
+	for _, want := range []string{"- agent: agent", "- rag: rag", `"repo": "acme/planner"`, `"other"`} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected prompt to contain %q", want)
+		}
+	}
+}
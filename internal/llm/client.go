@@ -74,14 +74,21 @@ Instructions:
 3. Comment on dark horse projects (high score)
 4. Comment on repeater projects (consecutive appearances)
 5. Select 3-5 highlight repositories and provide specific insights for each
-6. Maintain a %s tone
-7. Do NOT fabricate numbers - only interpret the provided data
-8. Output valid JSON in this structure:
+6. If emerging_themes is present, comment in 1-2 sentences on what these clusters of repositories outside the configured categories suggest
+7. If leaderboards is present, comment in 1 sentence on each leaderboard, keyed by its metric
+8. forecast and projected_breakouts are model projections, not measurements. If projected_breakouts is present, comment on it in 1-2 sentences and always call the numbers projections
+9. Repositories in star_anomalies, or with anomalies in top_repos, failed star-manipulation checks. Do not select them as highlights and treat their star counts with caution
+10. Maintain a %s tone
+11. Do NOT fabricate numbers - only interpret the provided data
+12. Output valid JSON in this structure:
 {
   "intro": "...",
   "category_notes": {"category_name": "..."},
   "dark_horse_notes": "...",
   "repeaters_notes": "...",
+  "emerging_themes_notes": "...",
+  "leaderboard_notes": {"metric": "..."},
+  "breakout_notes": "...",
   "highlights": [
     {"repo": "owner/repo", "comment": "...", "tone": "neutral-analytical"}
   ]
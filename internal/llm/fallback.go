@@ -14,6 +14,10 @@ func GenerateTemplateFallback(summary models.SummaryJSON) models.LLMOutput {
 		DarkHorseNotes: generateDarkHorseNotesFallback(summary),
 		RepeatersNotes: generateRepeatersNotesFallback(summary),
 		Highlights:     generateHighlightsFallback(summary),
+
+		EmergingThemesNotes: generateEmergingThemesNotesFallback(summary),
+		LeaderboardNotes:    generateLeaderboardNotesFallback(summary),
+		BreakoutNotes:       generateBreakoutNotesFallback(summary),
 	}
 }
 
@@ -34,6 +38,9 @@ func generateCategoryNotesFallback(summary models.SummaryJSON) map[string]string
 	notes := make(map[string]string)
 
 	for _, cat := range summary.Categories {
+		if cat.Count == 0 {
+			continue
+		}
 		notes[cat.Name] = fmt.Sprintf(
 			"This category contains %d repositories with an average Heat_7 of %.0f stars and average score of %.0f.",
 			cat.Count,
@@ -71,6 +78,50 @@ func generateRepeatersNotesFallback(summary models.SummaryJSON) string {
 	)
 }
 
+// generateEmergingThemesNotesFallback creates template emerging theme notes
+func generateEmergingThemesNotesFallback(summary models.SummaryJSON) string {
+	if len(summary.EmergingThemes) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"Found %d clusters of similar repositories that no configured category covers, "+
+			"which may signal new topics worth tracking.",
+		len(summary.EmergingThemes),
+	)
+}
+
+// generateLeaderboardNotesFallback creates template notes naming each leaderboard's leader
+func generateLeaderboardNotesFallback(summary models.SummaryJSON) map[string]string {
+	notes := make(map[string]string)
+
+	for _, board := range summary.Leaderboards {
+		if len(board.Repos) == 0 {
+			continue
+		}
+		notes[board.Metric] = fmt.Sprintf(
+			"%s leads this ranking of %d repositories.",
+			board.Repos[0].RepoKey,
+			len(board.Repos),
+		)
+	}
+
+	return notes
+}
+
+// generateBreakoutNotesFallback creates template projected breakout notes
+func generateBreakoutNotesFallback(summary models.SummaryJSON) string {
+	if len(summary.Breakouts) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"%d repositories have star gains projected to keep growing over the next week; "+
+			"these are projections from earlier snapshots, not measured results.",
+		len(summary.Breakouts),
+	)
+}
+
 // generateHighlightsFallback creates template highlights
 func generateHighlightsFallback(summary models.SummaryJSON) []models.HighlightComment {
 	highlights := make([]models.HighlightComment, 0)
@@ -22,7 +22,7 @@ func NewRepoMetadata(owner string, name string, url string, description string,
 }
 
 // NewClassifiedRepo creates a new ClassifiedRepo instance
-func NewClassifiedRepo(metadata RepoMetadata, categories []string, primaryCategory string, matchScore int) *ClassifiedRepo {
+func NewClassifiedRepo(metadata RepoMetadata, categories []string, primaryCategory string, matchScore float64) *ClassifiedRepo {
 	return &ClassifiedRepo{
 		Metadata:        metadata,
 		Categories:      categories,
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
@@ -18,6 +19,24 @@ type RepoMetadata struct {
 	StarsThisWeek  int       `json:"stars_this_week"`
 	StarsThisMonth int       `json:"stars_this_month"`
 	CreatedAt      time.Time `json:"created_at"`
+
+	// TrendingWindows lists the trending pages (daily, weekly, monthly) the repository
+	// appeared on. Snapshots saved before it was recorded leave it empty
+	TrendingWindows []string `json:"trending_windows,omitempty"`
+
+	// Fields populated by GraphQL enrichment
+	// ID is GitHub's node ID, which survives renames and transfers
+	ID              string    `json:"id,omitempty"`
+	PushedAt        time.Time `json:"pushed_at"`
+	PrimaryLanguage string    `json:"primary_language,omitempty"`
+	License         string    `json:"license,omitempty"`
+	IsArchived      bool      `json:"is_archived"`
+	IsFork          bool      `json:"is_fork"`
+	Watchers        int       `json:"watchers,omitempty"`
+	Enriched        bool      `json:"enriched"`
+
+	// Readme holds markdown-stripped, truncated README text when README fetching is enabled
+	Readme string `json:"readme,omitempty"`
 }
 
 // Key returns the repository key in format "owner/repo"
@@ -25,12 +44,151 @@ func (r *RepoMetadata) Key() string {
 	return r.Owner + "/" + r.Name
 }
 
+// HistoryID returns the key a repository's history is stored under: its stable ID
+// when known, else its owner/repo key
+func (r *RepoMetadata) HistoryID() string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return r.Key()
+}
+
+// MatchKeys returns the keys a repository is matched by across snapshots, most
+// reliable first: its stable ID when known, then its lowercase owner/repo key
+func (r *RepoMetadata) MatchKeys() []string {
+	key := strings.ToLower(r.Key())
+	if r.ID != "" {
+		return []string{r.ID, key}
+	}
+	return []string{key}
+}
+
+// Trending windows, named after the since parameter of the trending pages
+const (
+	WindowDaily   = "daily"
+	WindowWeekly  = "weekly"
+	WindowMonthly = "monthly"
+)
+
+// OnWindow reports whether the repository appeared on a trending window. Without
+// recorded windows, a non-zero star count for the window counts as an appearance
+func (r *RepoMetadata) OnWindow(window string) bool {
+	if len(r.TrendingWindows) == 0 {
+		switch window {
+		case WindowDaily:
+			return r.StarsToday > 0
+		case WindowWeekly:
+			return r.StarsThisWeek > 0
+		case WindowMonthly:
+			return r.StarsThisMonth > 0
+		}
+		return false
+	}
+	for _, w := range r.TrendingWindows {
+		if w == window {
+			return true
+		}
+	}
+	return false
+}
+
+// TrendingSnapshot is a saved trending fetch and the date it was taken
+type TrendingSnapshot struct {
+	Date  time.Time
+	Repos []RepoMetadata
+}
+
 // ClassifiedRepo represents a repository with category assignments
 type ClassifiedRepo struct {
 	Metadata        RepoMetadata `json:"metadata"`
 	Categories      []string     `json:"categories"`
 	PrimaryCategory string       `json:"primary_category"`
-	MatchScore      int          `json:"match_score"`
+	MatchScore      float64      `json:"match_score"`
+	ContentType     string       `json:"content_type,omitempty"`
+
+	// Curation overrides applied to this repository
+	DisplayName string `json:"display_name,omitempty"`
+	CuratorNote string `json:"curator_note,omitempty"`
+	Pinned      bool   `json:"pinned,omitempty"`
+
+	// LLMCategory records the LLM's decision for a repository that matched no category
+	LLMCategory *CategoryDecision `json:"llm_category,omitempty"`
+
+	// Trace records how the classifier reached this result
+	Trace *ClassificationTrace `json:"trace,omitempty"`
+
+	// Anomalies holds the star-manipulation checks this repository failed
+	Anomalies []AnomalyFlag `json:"anomalies,omitempty"`
+}
+
+// Stargazer is one sampled star and the account that gave it
+type Stargazer struct {
+	StarredAt        time.Time `json:"starred_at"`
+	AccountCreatedAt time.Time `json:"account_created_at"`
+	Followers        int       `json:"followers"`
+	Repositories     int       `json:"repositories"`
+}
+
+// AnomalyFlag is a failed star-manipulation check and the evidence for it
+type AnomalyFlag struct {
+	Check     string  `json:"check"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Evidence  string  `json:"evidence"`
+}
+
+// AnomalyReport records a screened repository that failed at least one check
+type AnomalyReport struct {
+	RepoKey  string        `json:"repo_key"`
+	URL      string        `json:"url"`
+	Excluded bool          `json:"excluded"`
+	Flags    []AnomalyFlag `json:"flags"`
+}
+
+// CategoryDecision is an LLM category assignment; Category is "other" when none fits
+type CategoryDecision struct {
+	Category   string  `json:"category"`
+	Confidence float64 `json:"confidence"`
+}
+
+// ClassificationTrace explains a single classification decision
+type ClassificationTrace struct {
+	Included bool   `json:"included"`
+	Reason   string `json:"reason"`
+
+	// MatchedKeywords maps a field (name, description, topics, readme) to the
+	// include and category keywords found in it
+	MatchedKeywords map[string][]string `json:"matched_keywords,omitempty"`
+	MatchedRules    []string            `json:"matched_rules,omitempty"`
+	ExcludedBy      string              `json:"excluded_by,omitempty"`
+
+	// ContentType is the detected kind of repository (project, list, tutorial, ...)
+	// and ContentSignals the evidence for it
+	ContentType    string   `json:"content_type,omitempty"`
+	ContentSignals []string `json:"content_signals,omitempty"`
+
+	MatchScore      float64         `json:"match_score"`
+	MinMatchScore   float64         `json:"min_match_score,omitempty"`
+	Categories      []CategoryTrace `json:"categories,omitempty"`
+	PrimaryCategory string          `json:"primary_category,omitempty"`
+	TieBreak        string          `json:"tie_break,omitempty"`
+
+	// Similarities holds the cosine similarity to each category prototype
+	// when semantic classification is enabled
+	Similarities map[string]float64 `json:"similarities,omitempty"`
+
+	// Override describes a curation override that changed the outcome
+	Override string `json:"override,omitempty"`
+}
+
+// CategoryTrace records the keyword and rule matches of one assigned category
+type CategoryTrace struct {
+	Name       string   `json:"name"`
+	Keywords   []string `json:"keywords,omitempty"`
+	Rules      []string `json:"rules,omitempty"`
+	MatchCount int      `json:"match_count"`
+	Score      float64  `json:"score"`
+	Similarity float64  `json:"similarity,omitempty"`
 }
 
 // Key returns the repository key
@@ -46,6 +204,56 @@ type ScoredRepo struct {
 	Heat30     int            `json:"heat_30"`
 	Prev30     int            `json:"prev_30"`
 	Score      int            `json:"score"`
+	StarsToday int            `json:"stars_today"`
+
+	// Metrics records whether each star window was observed, missing or estimated,
+	// and Confidence how much the score can be trusted as a result
+	Metrics    MetricStatus `json:"metrics"`
+	Confidence string       `json:"confidence"`
+
+	// Forecast projects the stars of the coming days; nil without enough history
+	Forecast *Forecast `json:"forecast,omitempty"`
+
+	// Lifecycle is the stage of the repository: emerging, accelerating, peaking,
+	// cooling or evergreen
+	Lifecycle string `json:"lifecycle,omitempty"`
+}
+
+// Forecast is a model projection of the stars a repository will gain, not a measurement
+type Forecast struct {
+	Days  int `json:"days"`
+	Stars int `json:"stars"`
+	// Low and High bound the projection by one standard deviation of the fit
+	Low  int `json:"low"`
+	High int `json:"high"`
+	// DailyGrowth is the fitted day-over-day growth of daily star gains, e.g. 0.1 for +10% a day
+	DailyGrowth float64 `json:"daily_growth"`
+	// Points is the number of snapshots the fit used
+	Points int `json:"points"`
+}
+
+// Metric statuses
+const (
+	MetricObserved  = "observed"
+	MetricMissing   = "missing"
+	MetricEstimated = "estimated"
+)
+
+// Score confidence levels
+const (
+	// ConfidenceHigh means every star window was observed
+	ConfidenceHigh = "high"
+	// ConfidenceMedium means some windows were estimated from prior snapshots
+	ConfidenceMedium = "medium"
+	// ConfidenceLow means at least one window is missing and counted as 0
+	ConfidenceLow = "low"
+)
+
+// MetricStatus records the status of each star window
+type MetricStatus struct {
+	Today string `json:"today"`
+	Week  string `json:"week"`
+	Month string `json:"month"`
 }
 
 // Key returns the repository key
@@ -65,12 +273,24 @@ type RepoHistory struct {
 	LastSeenDate     string `json:"last_seen_date"`
 	FirstSeenReport  string `json:"first_seen_report"`
 	FirstSeenDate    string `json:"first_seen_date"`
+	// Key is the owner/repo key the repository was last seen under
+	Key string `json:"key,omitempty"`
+	// Aliases are former owner/repo keys of a renamed or transferred repository
+	Aliases []string `json:"aliases,omitempty"`
 }
 
-// History represents complete historical tracking
+// History represents complete historical tracking. Entries are keyed by stable
+// repository ID, or by owner/repo key for repositories whose ID is unknown
 type History struct {
 	LatestReport string                 `json:"latest_report"`
 	History      map[string]RepoHistory `json:"history"`
+	// Aliases maps the current and former owner/repo keys of ID-keyed entries to their ID
+	Aliases map[string]string `json:"aliases,omitempty"`
+	// Reports lists the applied report IDs in the order they were first applied
+	Reports []string `json:"reports,omitempty"`
+	// Previous is the history as it stood before LatestReport was applied, so re-running
+	// that report replaces its contribution instead of stacking it
+	Previous *History `json:"previous,omitempty"`
 }
 
 // NewHistory creates a new empty history
@@ -80,6 +300,35 @@ func NewHistory() *History {
 	}
 }
 
+// Find returns the key of a repository's history entry, looking it up by stable ID,
+// then by current or former owner/repo key
+func (h *History) Find(repo *RepoMetadata) (string, bool) {
+	if repo.ID != "" {
+		if _, exists := h.History[repo.ID]; exists {
+			return repo.ID, true
+		}
+	}
+	key := repo.Key()
+	if id, exists := h.Aliases[key]; exists {
+		if _, exists := h.History[id]; exists {
+			return id, true
+		}
+	}
+	if _, exists := h.History[key]; exists {
+		return key, true
+	}
+	return "", false
+}
+
+// Entry returns the history of a repository
+func (h *History) Entry(repo *RepoMetadata) (RepoHistory, bool) {
+	key, exists := h.Find(repo)
+	if !exists {
+		return RepoHistory{}, false
+	}
+	return h.History[key], true
+}
+
 // MetaInfo represents metadata for the summary
 type MetaInfo struct {
 	RunDate          string `json:"run_date"`
@@ -87,6 +336,9 @@ type MetaInfo struct {
 	ShortWindowDays  int    `json:"short_window_days"`
 	TopN             int    `json:"top_n"`
 	FilterDomain     string `json:"filter_domain"`
+	// Shortfall is how many places the top N came up short, when the selection
+	// constraints or the candidate pool left fewer than TopN repositories
+	Shortfall int `json:"shortfall,omitempty"`
 }
 
 // CategoryStats represents statistics for a category
@@ -95,6 +347,8 @@ type CategoryStats struct {
 	Count       int     `json:"count"`
 	AvgHeat7    float64 `json:"avg_heat_7"`
 	AvgScore    float64 `json:"avg_score"`
+	// Lifecycle counts the category's repositories in each lifecycle stage
+	Lifecycle map[string]int `json:"lifecycle,omitempty"`
 }
 
 // LanguageStats represents statistics for a language
@@ -135,6 +389,8 @@ type RepeaterInfo struct {
 type TopRepoInfo struct {
 	Rank     int    `json:"rank"`
 	RepoKey  string `json:"repo_key"`
+	// RepoID is the stable repository ID, when known
+	RepoID   string `json:"repo_id,omitempty"`
 	RepoName string `json:"repo_name"`
 	URL      string `json:"url"`
 	Category string `json:"category"`
@@ -143,17 +399,78 @@ type TopRepoInfo struct {
 	Heat30   int    `json:"heat_30"`
 	Score    int    `json:"score"`
 	Description string `json:"description"`
+	DisplayName string `json:"display_name,omitempty"`
+	CuratorNote string `json:"curator_note,omitempty"`
+	Pinned      bool   `json:"pinned,omitempty"`
+	// Confidence is set when the score rests on estimated or missing star windows
+	Confidence string `json:"confidence,omitempty"`
+	// Forecast is a projection, set when forecasting is enabled and history suffices
+	Forecast  *Forecast `json:"forecast,omitempty"`
+	Lifecycle string    `json:"lifecycle,omitempty"`
+	// Anomalies names the star-manipulation checks the repository failed
+	Anomalies []string `json:"anomalies,omitempty"`
+}
+
+// BreakoutInfo is a repository whose projected star gains grow fastest
+type BreakoutInfo struct {
+	RepoKey  string    `json:"repo_key"`
+	RepoName string    `json:"repo_name"`
+	URL      string    `json:"url"`
+	Category string    `json:"category"`
+	Heat7    int       `json:"heat_7"`
+	Forecast *Forecast `json:"forecast"`
+}
+
+// EmergingTheme is a cluster of similar repositories that no configured category covers
+type EmergingTheme struct {
+	Label            string   `json:"label"`
+	Terms            []string `json:"terms"`
+	Repos            []string `json:"repos"`
+	AvgHeat7         float64  `json:"avg_heat_7"`
+	DominantCategory string   `json:"dominant_category,omitempty"`
+	CategoryShare    float64  `json:"category_share"`
+}
+
+// Leaderboard is an additional ranking of the scored repositories by one metric
+type Leaderboard struct {
+	Metric string             `json:"metric"`
+	Title  string             `json:"title"`
+	Repos  []LeaderboardEntry `json:"repos"`
+}
+
+// LeaderboardEntry is one repository in a leaderboard
+type LeaderboardEntry struct {
+	Rank        int    `json:"rank"`
+	RepoKey     string `json:"repo_key"`
+	RepoName    string `json:"repo_name"`
+	URL         string `json:"url"`
+	Category    string `json:"category"`
+	StarsToday  int    `json:"stars_today"`
+	Heat7       int    `json:"heat_7"`
+	Heat30      int    `json:"heat_30"`
+	Score       int    `json:"score"`
+	TotalStars  int    `json:"total_stars"`
+	DisplayName string `json:"display_name,omitempty"`
+
+	// RelativeGrowth is Heat_7 as a fraction of the stars held before the week
+	RelativeGrowth float64 `json:"relative_growth"`
 }
 
 // SummaryJSON represents the complete summary for LLM
 type SummaryJSON struct {
-	Meta       MetaInfo        `json:"meta"`
-	Categories []CategoryStats `json:"categories"`
-	Languages  []LanguageStats `json:"languages"`
-	NewRepos   NewReposInfo    `json:"new_repos"`
-	DarkHorses []DarkHorseInfo `json:"dark_horses"`
-	Repeaters  []RepeaterInfo  `json:"repeaters"`
-	TopRepos   []TopRepoInfo   `json:"top_repos"`
+	Meta           MetaInfo        `json:"meta"`
+	Categories     []CategoryStats `json:"categories"`
+	Languages      []LanguageStats `json:"languages"`
+	NewRepos       NewReposInfo    `json:"new_repos"`
+	DarkHorses     []DarkHorseInfo `json:"dark_horses"`
+	Repeaters      []RepeaterInfo  `json:"repeaters"`
+	TopRepos       []TopRepoInfo   `json:"top_repos"`
+	EmergingThemes []EmergingTheme `json:"emerging_themes,omitempty"`
+	Leaderboards   []Leaderboard   `json:"leaderboards,omitempty"`
+	// Breakouts are model projections, not measurements
+	Breakouts []BreakoutInfo `json:"projected_breakouts,omitempty"`
+	// Anomalies lists repositories flagged by star-manipulation screening
+	Anomalies []AnomalyReport `json:"star_anomalies,omitempty"`
 }
 
 // HighlightComment represents a highlighted repository comment
@@ -169,6 +486,10 @@ type LLMOutput struct {
 	CategoryNotes   map[string]string           `json:"category_notes"`
 	DarkHorseNotes  string                      `json:"dark_horse_notes"`
 	RepeatersNotes  string                      `json:"repeaters_notes"`
+	EmergingThemesNotes string                  `json:"emerging_themes_notes,omitempty"`
+	// LeaderboardNotes maps a leaderboard metric to commentary on it
+	LeaderboardNotes map[string]string `json:"leaderboard_notes,omitempty"`
+	BreakoutNotes    string            `json:"breakout_notes,omitempty"`
 	Highlights      []HighlightComment          `json:"highlights"`
 }
 
@@ -0,0 +1,28 @@
+package pipeline
+
+import (
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/classifier"
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/embedding"
+)
+
+// NewRepoClassifier builds the classifier selected by settings.classifier_mode.
+// Semantic modes need an LLM API key; without one the keyword classifier is used.
+func NewRepoClassifier(cfg config.Config, apiKey string, logger zerolog.Logger) classifier.RepoClassifier {
+	keywordClassifier := classifier.New(cfg.Keywords).WithOverrides(cfg.Overrides)
+
+	mode := cfg.Settings.ClassifierMode
+	if mode == "" || mode == config.ClassifierModeKeyword {
+		return keywordClassifier
+	}
+	if apiKey == "" {
+		logger.Warn().Str("classifier_mode", mode).Msg("LLM_API_KEY not set, using keyword classifier")
+		return keywordClassifier
+	}
+
+	embedder := embedding.New(cfg.LLM, apiKey, logger)
+	hybrid := mode == config.ClassifierModeHybrid
+	return classifier.NewEmbeddingClassifier(keywordClassifier, embedder, hybrid, logger)
+}
@@ -1,28 +1,52 @@
 package pipeline
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
 
+	"ai-repo-insights/internal/anomaly"
 	"ai-repo-insights/internal/calculator"
 	"ai-repo-insights/internal/classifier"
 	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/enricher"
 	apperrors "ai-repo-insights/internal/errors"
 	"ai-repo-insights/internal/fetcher"
 	"ai-repo-insights/internal/history"
+	"ai-repo-insights/internal/lifecycle"
 	"ai-repo-insights/internal/llm"
 	"ai-repo-insights/internal/models"
+	"ai-repo-insights/internal/readme"
 	"ai-repo-insights/internal/report"
 	"ai-repo-insights/internal/summary"
+	"ai-repo-insights/internal/themes"
+)
+
+const (
+	// maxEmergingThemes caps the themes shown in the summary and report
+	maxEmergingThemes = 5
+	// themeLabelTerms is the number of top terms in a theme label
+	themeLabelTerms = 3
+
+	// maxBreakouts caps the repositories listed as projected to break out
+	maxBreakouts = 5
+
+	// SummariesDir holds the summary backup of each report
+	SummariesDir = "data/summaries"
+	// historyPath is the consecutive appearances history file
+	historyPath = "data/history.json"
 )
 
 // Orchestrator executes complete workflow with error handling and logging
 type Orchestrator struct {
-	config config.Config
-	logger zerolog.Logger
+	config       config.Config
+	logger       zerolog.Logger
+	forceHistory bool
 }
 
 // NewOrchestrator creates a new pipeline orchestrator
@@ -33,6 +57,13 @@ func NewOrchestrator(cfg config.Config, logger zerolog.Logger) *Orchestrator {
 	}
 }
 
+// WithForceHistory recomputes history from the stored top list of every report instead
+// of updating it incrementally
+func (o *Orchestrator) WithForceHistory(force bool) *Orchestrator {
+	o.forceHistory = force
+	return o
+}
+
 // RunPipeline executes complete pipeline
 func (o *Orchestrator) RunPipeline(reportID string) (models.PipelineResult, error) {
 	o.logger.Info().Str("report_id", reportID).Msg("starting pipeline execution")
@@ -43,6 +74,11 @@ func (o *Orchestrator) RunPipeline(reportID string) (models.PipelineResult, erro
 		reportID = o.generateReportID()
 	}
 
+	// Re-running an earlier report cannot update history; stop before any fetching
+	if err := o.checkRerun(reportID); err != nil {
+		return models.PipelineResult{Success: false, Error: err.Error()}, err
+	}
+
 	// 1. Fetch trending
 	stepStart := time.Now()
 	o.logger.Info().Msg("step 1: fetching trending repositories")
@@ -55,6 +91,24 @@ func (o *Orchestrator) RunPipeline(reportID string) (models.PipelineResult, erro
 			apperrors.NewDataFetchError("failed to fetch trending data", err)
 	}
 	
+	// Enrich with GraphQL metadata before saving so snapshots carry the full record
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	if githubToken != "" {
+		metadataEnricher := enricher.New(githubToken, o.logger)
+		enrichedRepos, err := metadataEnricher.Enrich(trendingRepos)
+		if err != nil {
+			o.logger.Warn().Err(err).Msg("metadata enrichment failed, continuing with trending data only")
+		}
+		trendingRepos = enrichedRepos
+	} else {
+		o.logger.Warn().Msg("GITHUB_TOKEN not set, skipping metadata enrichment")
+	}
+	
+	if o.config.Settings.FetchReadme {
+		readmeFetcher := readme.New(githubToken, o.config.Settings.CacheTTLHours, o.config.Settings.ReadmeMaxChars, o.logger)
+		trendingRepos = readmeFetcher.FetchAll(trendingRepos)
+	}
+	
 	if err := trendingFetcher.SaveRaw(trendingRepos, now); err != nil {
 		o.logger.Warn().Err(err).Msg("failed to save raw trending data")
 	}
@@ -68,7 +122,7 @@ func (o *Orchestrator) RunPipeline(reportID string) (models.PipelineResult, erro
 	stepStart = time.Now()
 	o.logger.Info().Msg("step 2: classifying repositories")
 	
-	repoClassifier := classifier.New(o.config.Keywords)
+	repoClassifier := NewRepoClassifier(o.config, os.Getenv("LLM_API_KEY"), o.logger)
 	classifiedRepos := repoClassifier.Classify(trendingRepos)
 	
 	o.logger.Info().
@@ -81,13 +135,59 @@ func (o *Orchestrator) RunPipeline(reportID string) (models.PipelineResult, erro
 			fmt.Errorf("no repositories matched filter criteria")
 	}
 
+	if o.config.Settings.LLMCategorize {
+		o.categorizeUncategorized(classifiedRepos)
+	}
+
+	var anomalies []models.AnomalyReport
+	if o.config.Settings.AnomalyScreening.Action != config.AnomalyActionOff {
+		classifiedRepos, anomalies = o.screenAnomalies(classifiedRepos, githubToken)
+		if len(classifiedRepos) == 0 {
+			return models.PipelineResult{Success: false, Error: "no repositories left after anomaly screening"},
+				fmt.Errorf("no repositories left after anomaly screening")
+		}
+	}
+
 	// 3. Calculate scores
 	stepStart = time.Now()
 	o.logger.Info().Msg("step 3: calculating scores")
 	
-	calc := calculator.New(o.config.Settings.WindowDays, o.config.Settings.ShortWindowDays)
+	calc := calculator.New(o.config.Settings.WindowDays, o.config.Settings.ShortWindowDays).
+		WithWeights(o.config.Settings.ScoreWeights)
+	// Prior snapshots feed imputation, forecasts and lifecycle stages; the monthly
+	// window is the longest a prior snapshot can stand in for
+	lookback := 30
+	if o.config.Settings.Forecast && o.config.Settings.ForecastLookbackDays > lookback {
+		lookback = o.config.Settings.ForecastLookbackDays
+	}
+	priors, err := fetcher.LoadPriorRaw(fetcher.RawDir, now, lookback)
+	if err != nil {
+		o.logger.Warn().Err(err).Msg("failed to load prior snapshots, continuing without them")
+	}
+	if o.config.Settings.ImputeMissingWindows {
+		calc.WithPriorSnapshots(now, priors)
+	}
+	if o.config.Settings.Forecast {
+		calc.WithForecast(now, priors, o.config.Settings.ForecastLookbackDays)
+	}
+	calc.WithConstraints(calculator.Constraints{
+		MaxPerCategory: o.config.Settings.MaxPerCategory,
+		MaxPerOwner:    o.config.Settings.MaxPerOwner,
+		MinPerCategory: o.config.Settings.MinPerCategory,
+		MinPerLanguage: o.config.Settings.MinPerLanguage,
+	})
 	scoredRepos := calc.CalculateScores(classifiedRepos)
 	topRepos := calc.RankAndSelectTop(scoredRepos, o.config.Settings.TopN)
+	if len(topRepos) < o.config.Settings.TopN {
+		o.logger.Warn().
+			Int("selected", len(topRepos)).
+			Int("top_n", o.config.Settings.TopN).
+			Int("candidates", len(scoredRepos)).
+			Msg("top list is shorter than top_n, check the diversity caps")
+	}
+
+	// Themes are mined from every classified repo, not just the top N
+	emergingThemes := o.discoverThemes(scoredRepos)
 	
 	o.logger.Info().
 		Int("top_count", len(topRepos)).
@@ -100,21 +200,28 @@ func (o *Orchestrator) RunPipeline(reportID string) (models.PipelineResult, erro
 	
 	now = time.Now()
 	runDate := now.Format("2006-01-02")
-	historyManager := history.NewManager("data/history.json", o.logger)
+	historyManager := history.NewManager(historyPath, o.logger)
 	hist, err := historyManager.LoadHistory()
 	if err != nil {
 		o.logger.Warn().Err(err).Msg("failed to load history, starting fresh")
 		hist = models.NewHistory()
 	}
 	
-	hist, err = historyManager.UpdateHistory(topRepos, reportID, runDate)
-	if err != nil {
-		o.logger.Warn().Err(err).Msg("failed to update history")
+	if o.forceHistory {
+		hist = o.rebuildHistory(historyManager, hist, topRepos, reportID, runDate)
+	} else {
+		hist, err = historyManager.UpdateHistory(topRepos, reportID, runDate)
+		if err != nil {
+			o.logger.Warn().Err(err).Msg("failed to update history")
+		}
 	}
 	
 	if err := historyManager.SaveHistory(hist); err != nil {
 		o.logger.Warn().Err(err).Msg("failed to save history")
 	}
+
+	// Streaks in the updated history include this report
+	lifecycle.NewLabeler(now, priors).Label(topRepos, hist)
 	
 	o.logger.Info().
 		Dur("duration", time.Since(stepStart)).
@@ -126,6 +233,10 @@ func (o *Orchestrator) RunPipeline(reportID string) (models.PipelineResult, erro
 	
 	summaryBuilder := summary.NewBuilder(o.config.Settings)
 	summaryJSON := summaryBuilder.BuildSummary(topRepos, hist, runDate)
+	summaryJSON.EmergingThemes = emergingThemes
+	summaryJSON.Leaderboards = summaryBuilder.BuildLeaderboards(scoredRepos)
+	summaryJSON.Breakouts = summaryBuilder.BuildBreakouts(scoredRepos, maxBreakouts)
+	summaryJSON.Anomalies = anomalies
 	
 	o.logger.Info().
 		Dur("duration", time.Since(stepStart)).
@@ -200,12 +311,11 @@ func (o *Orchestrator) generateReportID() string {
 
 // saveSummaryBackup saves summary JSON to data/summaries/
 func (o *Orchestrator) saveSummaryBackup(summaryJSON models.SummaryJSON, reportID string) error {
-	summariesDir := "data/summaries"
-	if err := os.MkdirAll(summariesDir, 0755); err != nil {
+	if err := os.MkdirAll(SummariesDir, 0755); err != nil {
 		return err
 	}
 
-	filename := fmt.Sprintf("%s/%s.json", summariesDir, reportID)
+	filename := fmt.Sprintf("%s/%s.json", SummariesDir, reportID)
 	
 	data, err := models.MarshalJSON(summaryJSON)
 	if err != nil {
@@ -214,3 +324,152 @@ func (o *Orchestrator) saveSummaryBackup(summaryJSON models.SummaryJSON, reportI
 
 	return os.WriteFile(filename, data, 0644)
 }
+
+// checkRerun refuses to re-run a report that was applied to history before the latest
+// report, unless history is being rebuilt
+func (o *Orchestrator) checkRerun(reportID string) error {
+	if o.forceHistory {
+		return nil
+	}
+	hist, err := history.NewManager(historyPath, o.logger).LoadHistory()
+	if err != nil {
+		// Step 4 starts fresh from an unreadable history
+		return nil
+	}
+	if history.IsEarlierReport(hist, reportID) {
+		return apperrors.NewConfigError(fmt.Sprintf(
+			"report %s was applied to history before the latest report %s; use -force to recompute history",
+			reportID, hist.LatestReport), nil)
+	}
+	return nil
+}
+
+// rebuildHistory recomputes history by replaying the top list of every earlier report
+// from its summary backup, then this report's. Reports keep the order they were first
+// applied in; reports missing from that order follow by run date.
+func (o *Orchestrator) rebuildHistory(
+	historyManager *history.Manager,
+	current *models.History,
+	topRepos []models.ScoredRepo,
+	reportID string,
+	runDate string,
+) *models.History {
+	rankings, err := LoadSummaryRankings(SummariesDir, reportID)
+	if err != nil {
+		o.logger.Warn().Err(err).Msg("failed to load stored rankings, rebuilding from this report only")
+	}
+
+	repos := make([]models.RepoMetadata, len(topRepos))
+	for i, repo := range topRepos {
+		repos[i] = repo.Repo.Metadata
+	}
+	rankings = append(rankings, history.Ranking{ReportID: reportID, Date: runDate, Repos: repos})
+
+	history.SortRankings(rankings, current.Reports)
+	return historyManager.Rebuild(rankings)
+}
+
+// LoadSummaryRankings reads the top list of each summary backup in dir except reportID's
+func LoadSummaryRankings(dir string, reportID string) ([]history.Ranking, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var rankings []history.Ranking
+	for _, path := range paths {
+		backupID := strings.TrimSuffix(filepath.Base(path), ".json")
+		if backupID == reportID {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return rankings, apperrors.NewFilesystemError("failed to read summary backup", path, err)
+		}
+		var backup models.SummaryJSON
+		if err := json.Unmarshal(data, &backup); err != nil {
+			return rankings, apperrors.NewFilesystemError("failed to parse summary backup", path, err)
+		}
+
+		repos := make([]models.RepoMetadata, 0, len(backup.TopRepos))
+		for _, top := range backup.TopRepos {
+			owner, name, _ := strings.Cut(top.RepoKey, "/")
+			repos = append(repos, models.RepoMetadata{Owner: owner, Name: name, ID: top.RepoID})
+		}
+		rankings = append(rankings, history.Ranking{ReportID: backupID, Date: backup.Meta.RunDate, Repos: repos})
+	}
+
+	return rankings, nil
+}
+
+// categorizeUncategorized asks the LLM to categorize repositories that matched no category;
+// anything left over is reported in the Other bucket
+func (o *Orchestrator) categorizeUncategorized(repos []models.ClassifiedRepo) {
+	llmAPIKey := os.Getenv("LLM_API_KEY")
+	if llmAPIKey == "" {
+		o.logger.Warn().Msg("LLM_API_KEY not set, skipping LLM categorization")
+		return
+	}
+
+	categorizer := llm.NewCategorizer(
+		llm.NewClient(o.config.LLM, llmAPIKey, o.logger),
+		o.config.Keywords,
+		o.config.Settings.LLMCategoryMinConfidence,
+		o.logger,
+	)
+	assigned, err := categorizer.Categorize(repos)
+	if err != nil {
+		o.logger.Warn().Err(err).Msg("LLM categorization incomplete")
+	}
+	o.logger.Info().Int("assigned", assigned).Msg("LLM categorization completed")
+}
+
+// screenAnomalies flags repositories with suspicious star patterns and, when configured,
+// drops them. Stargazers are sampled only with a GitHub token; without one just the
+// star ratio checks run.
+func (o *Orchestrator) screenAnomalies(repos []models.ClassifiedRepo, githubToken string) ([]models.ClassifiedRepo, []models.AnomalyReport) {
+	settings := o.config.Settings.AnomalyScreening
+
+	var samples map[string][]models.Stargazer
+	if githubToken != "" {
+		metadata := make([]models.RepoMetadata, len(repos))
+		for i, repo := range repos {
+			metadata[i] = repo.Metadata
+		}
+		var err error
+		samples, err = enricher.New(githubToken, o.logger).SampleStargazers(metadata, settings.StargazerSample)
+		if err != nil {
+			o.logger.Warn().Err(err).Msg("stargazer sampling failed, screening star ratios only")
+		}
+	} else {
+		o.logger.Warn().Msg("GITHUB_TOKEN not set, screening star ratios only")
+	}
+
+	reports := anomaly.NewScreener(settings).ScreenAll(repos, samples)
+	if settings.Action == config.AnomalyActionExclude {
+		repos = anomaly.Exclude(repos)
+	}
+
+	o.logger.Info().
+		Int("flagged", len(reports)).
+		Str("action", settings.Action).
+		Msg("anomaly screening completed")
+	return repos, reports
+}
+
+// discoverThemes clusters repositories and keeps clusters that no category keyword covers
+func (o *Orchestrator) discoverThemes(repos []models.ScoredRepo) []models.EmergingTheme {
+	// Match terms with the same settings as the classifier that ran
+	keywordClassifier := classifier.New(o.config.Keywords)
+
+	emergingThemes := themes.Discover(repos, keywordClassifier.CoversCategoryTerm, themes.Options{
+		MinClusterSize:      o.config.Settings.EmergingThemeMinSize,
+		SimilarityThreshold: o.config.Settings.EmergingThemeSimilarity,
+		MaxThemes:           maxEmergingThemes,
+		LabelTerms:          themeLabelTerms,
+	})
+
+	o.logger.Info().Int("theme_count", len(emergingThemes)).Msg("emerging themes discovered")
+	return emergingThemes
+}
@@ -0,0 +1,122 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/history"
+	"ai-repo-insights/internal/models"
+)
+
+// writeSummary saves a summary backup listing the given owner/repo keys as its top list
+func writeSummary(t *testing.T, dir string, reportID string, runDate string, keys ...string) {
+	t.Helper()
+
+	summary := models.SummaryJSON{Meta: models.MetaInfo{RunDate: runDate}}
+	for i, key := range keys {
+		summary.TopRepos = append(summary.TopRepos, models.TopRepoInfo{Rank: i + 1, RepoKey: key, RepoID: "R_" + key})
+	}
+	data, err := models.MarshalJSON(summary)
+	if err != nil {
+		t.Fatalf("failed to encode summary: %v", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, reportID+".json"), data, 0644); err != nil {
+		t.Fatalf("failed to write summary: %v", err)
+	}
+}
+
+func TestLoadSummaryRankings(t *testing.T) {
+	dir := t.TempDir()
+	writeSummary(t, dir, "week1", "2024-01-07", "o/a", "o/b")
+	writeSummary(t, dir, "week2", "2024-01-14", "o/a")
+	writeSummary(t, dir, "week3", "2024-01-21", "o/c")
+
+	rankings, err := LoadSummaryRankings(dir, "week3")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(rankings) != 2 {
+		t.Fatalf("expected the report being run to be left out, got %d rankings", len(rankings))
+	}
+	first := rankings[0]
+	if first.ReportID != "week1" || first.Date != "2024-01-07" || len(first.Repos) != 2 {
+		t.Errorf("unexpected first ranking %+v", first)
+	}
+	if repo := first.Repos[1]; repo.Owner != "o" || repo.Name != "b" || repo.ID != "R_o/b" {
+		t.Errorf("expected owner, name and ID from the summary, got %+v", repo)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644); err != nil {
+		t.Fatalf("failed to write broken summary: %v", err)
+	}
+	if _, err := LoadSummaryRankings(dir, "week3"); err == nil {
+		t.Error("expected an error for an unreadable summary backup")
+	}
+}
+
+func TestRebuildHistory(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeSummary(t, SummariesDir, "week1", "2024-01-07", "o/a", "o/b")
+	writeSummary(t, SummariesDir, "week2", "2024-01-14", "o/a")
+	// A stale backup of the report being re-run must be replaced by its new top list
+	writeSummary(t, SummariesDir, "week3", "2024-01-21", "o/b")
+
+	o := NewOrchestrator(config.Config{}, zerolog.Nop()).WithForceHistory(true)
+	manager := history.NewManager(historyPath, zerolog.Nop())
+	current := &models.History{Reports: []string{"week1", "week2", "week3"}}
+	top := []models.ScoredRepo{
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: "a", ID: "R_o/a"}}},
+	}
+
+	rebuilt := o.rebuildHistory(manager, current, top, "week3", "2024-01-21")
+
+	if rebuilt.LatestReport != "week3" {
+		t.Errorf("expected week3 to be applied last, got %s", rebuilt.LatestReport)
+	}
+	if len(rebuilt.Reports) != 3 || rebuilt.Reports[0] != "week1" {
+		t.Errorf("expected reports in their first-run order, got %v", rebuilt.Reports)
+	}
+	entry, exists := rebuilt.Entry(&top[0].Repo.Metadata)
+	if !exists || entry.WeeksInTop != 3 {
+		t.Errorf("expected o/a in the top for 3 reports, got %+v", entry)
+	}
+	if _, exists := rebuilt.Entry(&models.RepoMetadata{Owner: "o", Name: "b", ID: "R_o/b"}); exists {
+		t.Error("expected o/b's streak to be broken")
+	}
+}
+
+func TestCheckRerun(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll("data", 0755); err != nil {
+		t.Fatalf("failed to create data dir: %v", err)
+	}
+	manager := history.NewManager(historyPath, zerolog.Nop())
+	if err := manager.SaveHistory(&models.History{
+		LatestReport: "week2",
+		Reports:      []string{"week1", "week2"},
+		History:      map[string]models.RepoHistory{},
+	}); err != nil {
+		t.Fatalf("failed to save history: %v", err)
+	}
+
+	o := NewOrchestrator(config.Config{}, zerolog.Nop())
+	if err := o.checkRerun("week1"); err == nil {
+		t.Error("expected re-running an earlier report to be refused")
+	}
+	for _, reportID := range []string{"week2", "week3"} {
+		if err := o.checkRerun(reportID); err != nil {
+			t.Errorf("%s: expected no error, got %v", reportID, err)
+		}
+	}
+	if err := o.WithForceHistory(true).checkRerun("week1"); err != nil {
+		t.Errorf("expected -force to allow re-running an earlier report, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package readme
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/errors"
+	"ai-repo-insights/internal/models"
+)
+
+const (
+	apiBaseURL      = "https://api.github.com"
+	defaultCacheDir = "data/cache/readme"
+)
+
+// Fetcher downloads repository READMEs and caches the stripped text on disk
+type Fetcher struct {
+	token      string
+	baseURL    string
+	cacheDir   string
+	cacheTTL   time.Duration
+	maxChars   int
+	httpClient *http.Client
+	logger     zerolog.Logger
+	// rateLimit is the last rate limit response; no request is sent before it resets
+	rateLimit *errors.AppError
+}
+
+// New creates a new README fetcher; token may be empty for unauthenticated access
+func New(token string, cacheTTLHours int, maxChars int, logger zerolog.Logger) *Fetcher {
+	return &Fetcher{
+		token:    token,
+		baseURL:  apiBaseURL,
+		cacheDir: defaultCacheDir,
+		cacheTTL: time.Duration(cacheTTLHours) * time.Hour,
+		maxChars: maxChars,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// FetchAll attaches README text to every repository that has one.
+// Failures are logged and leave the README empty; they never abort the run.
+func (f *Fetcher) FetchAll(repos []models.RepoMetadata) []models.RepoMetadata {
+	withReadme := make([]models.RepoMetadata, len(repos))
+	copy(withReadme, repos)
+
+	fetched := 0
+	for i := range withReadme {
+		text, err := f.Fetch(withReadme[i].Owner, withReadme[i].Name)
+		if err != nil {
+			if errors.IsRateLimit(err) {
+				// Cached READMEs are still served until the limit resets
+				continue
+			}
+			f.logger.Debug().Str("repo", withReadme[i].Key()).Err(err).Msg("Failed to fetch README")
+			continue
+		}
+		withReadme[i].Readme = text
+		if text != "" {
+			fetched++
+		}
+	}
+
+	f.logger.Info().Int("repos", len(withReadme)).Int("with_readme", fetched).Msg("README fetch completed")
+	return withReadme
+}
+
+// Fetch returns the stripped and truncated README text for a repository,
+// serving it from the disk cache when the cached copy is still fresh
+func (f *Fetcher) Fetch(owner string, name string) (string, error) {
+	cachePath := f.cachePath(owner, name)
+
+	if info, err := os.Stat(cachePath); err == nil && time.Since(info.ModTime()) < f.cacheTTL {
+		data, err := os.ReadFile(cachePath)
+		if err == nil {
+			return string(data), nil
+		}
+	}
+
+	raw, err := f.download(owner, name)
+	if err != nil {
+		return "", err
+	}
+
+	text := Truncate(StripMarkdown(raw), f.maxChars)
+
+	if err := f.writeCache(cachePath, text); err != nil {
+		f.logger.Warn().Err(err).Msg("Failed to cache README")
+	}
+
+	return text, nil
+}
+
+// download fetches the raw README content from the GitHub REST API
+func (f *Fetcher) download(owner string, name string) (string, error) {
+	if f.rateLimit != nil {
+		reset, known := f.rateLimit.ResetTime()
+		if !known || time.Now().Before(reset) {
+			return "", f.rateLimit
+		}
+		f.rateLimit = nil
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/readme", f.baseURL, owner, name)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.raw+json")
+	if f.token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return "", errors.NewNetworkError("failed to fetch README", err)
+	}
+	defer resp.Body.Close()
+
+	if rateLimit := errors.RateLimitFromResponse(resp, time.Now()); rateLimit != nil {
+		f.logger.Warn().Str("reset_time", rateLimit.Context["reset_time"]).Msg("GitHub rate limit reached, no further README requests until reset")
+		f.rateLimit = rateLimit
+		return "", rateLimit
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// Repositories without a README are cached as empty text
+		return "", nil
+	default:
+		return "", errors.NewRepoFetchError(owner, name, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.NewNetworkError("failed to read README", err)
+	}
+
+	return string(body), nil
+}
+
+// cachePath returns the cache file path for a repository
+func (f *Fetcher) cachePath(owner string, name string) string {
+	return filepath.Join(f.cacheDir, owner+"__"+name+".txt")
+}
+
+// writeCache stores stripped README text in the cache directory
+func (f *Fetcher) writeCache(path string, text string) error {
+	if err := os.MkdirAll(f.cacheDir, 0755); err != nil {
+		return errors.NewFilesystemError("failed to create README cache directory", f.cacheDir, err)
+	}
+
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return errors.NewFilesystemError("failed to write README cache", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package readme
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"ai-repo-insights/internal/models"
+)
+
+const sampleReadme = "# Agent Kit\n\n" +
+	"[![Build](https://img.shields.io/badge/build-passing-green)](https://ci.example.com)\n\n" +
+	"An **autonomous** agent framework built on [LangChain](https://langchain.com).\n\n" +
+	"```python\nimport secret_keyword\n```\n\n" +
+	"- Supports `rag` pipelines\n" +
+	"- <img src=\"demo.png\"> multi_agent orchestration\n"
+
+func newTestFetcher(t *testing.T, baseURL string, maxChars int) *Fetcher {
+	t.Helper()
+	f := New("test-token", 24, maxChars, zerolog.Nop())
+	f.baseURL = baseURL
+	f.cacheDir = t.TempDir()
+	return f
+}
+
+func TestStripMarkdown(t *testing.T) {
+	text := StripMarkdown(sampleReadme)
+
+	for _, want := range []string{"Agent Kit", "autonomous agent framework", "LangChain", "Supports rag pipelines", "multi_agent orchestration"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected stripped text to contain %q, got %q", want, text)
+		}
+	}
+
+	for _, unwanted := range []string{"secret_keyword", "shields.io", "https://", "**", "#", "<img", "`"} {
+		if strings.Contains(text, unwanted) {
+			t.Errorf("expected stripped text not to contain %q, got %q", unwanted, text)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxChars int
+		expected string
+	}{
+		{"shorter than limit", "hello", 10, "hello"},
+		{"truncates ascii", "hello world", 5, "hello"},
+		{"does not split runes", "智能体框架", 2, "智能"},
+		{"zero disables truncation", "hello", 0, "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Truncate(tt.text, tt.maxChars); result != tt.expected {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.text, tt.maxChars, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFetch_UsesCache(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/repos/owner/repo/readme" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(sampleReadme))
+	}))
+	defer server.Close()
+
+	f := newTestFetcher(t, server.URL, 30)
+
+	first, err := f.Fetch("owner", "repo")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len([]rune(first)) > 30 {
+		t.Errorf("expected text truncated to 30 chars, got %d", len([]rune(first)))
+	}
+
+	if _, err := os.Stat(filepath.Join(f.cacheDir, "owner__repo.txt")); err != nil {
+		t.Errorf("expected cache file to be written: %v", err)
+	}
+
+	second, err := f.Fetch("owner", "repo")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if second != first {
+		t.Errorf("expected cached text %q, got %q", first, second)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected 1 request with cache hit, got %d", got)
+	}
+}
+
+func TestFetchAll_MissingReadme(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/norepo/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if strings.Contains(r.URL.Path, "/broken/") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("An LLM toolkit"))
+	}))
+	defer server.Close()
+
+	f := newTestFetcher(t, server.URL, 100)
+
+	repos := []models.RepoMetadata{
+		{Owner: "owner", Name: "repo"},
+		{Owner: "owner", Name: "norepo"},
+		{Owner: "owner", Name: "broken"},
+	}
+
+	result := f.FetchAll(repos)
+
+	if result[0].Readme != "An LLM toolkit" {
+		t.Errorf("expected README text, got %q", result[0].Readme)
+	}
+	if result[1].Readme != "" {
+		t.Errorf("expected empty README for missing file, got %q", result[1].Readme)
+	}
+	if result[2].Readme != "" {
+		t.Errorf("expected empty README on server error, got %q", result[2].Readme)
+	}
+	if repos[0].Readme != "" {
+		t.Error("expected input repos to be left untouched")
+	}
+}
+
+func TestFetchAll_RateLimited(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	f := newTestFetcher(t, server.URL, 100)
+
+	repos := []models.RepoMetadata{
+		{Owner: "owner", Name: "one"},
+		{Owner: "owner", Name: "two"},
+		{Owner: "owner", Name: "three"},
+	}
+	f.FetchAll(repos)
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected no requests after the rate limit response, got %d", got)
+	}
+}
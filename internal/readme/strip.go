@@ -0,0 +1,62 @@
+package readme
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	fencedCodePattern  = regexp.MustCompile("(?s)```.*?```|~~~.*?~~~")
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlTagPattern     = regexp.MustCompile(`<[^>]+>`)
+	imagePattern       = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	linkPattern        = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	refLinkPattern     = regexp.MustCompile(`(?m)^\s*\[[^\]]+\]:\s*\S+.*$`)
+	inlineCodePattern  = regexp.MustCompile("`([^`]*)`")
+	urlPattern         = regexp.MustCompile(`https?://\S+`)
+	headingPattern     = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s*`)
+	listMarkerPattern  = regexp.MustCompile(`(?m)^\s*(?:[-*+]|\d+\.)\s+`)
+	blockquotePattern  = regexp.MustCompile(`(?m)^\s*>\s?`)
+	tableRulePattern   = regexp.MustCompile(`(?m)^\s*\|?[\s:|-]+\|[\s:|-]*$`)
+	emphasisPattern    = regexp.MustCompile(`\*{1,3}|~~`)
+	whitespacePattern  = regexp.MustCompile(`\s+`)
+)
+
+// StripMarkdown reduces README markdown to plain prose suitable for keyword matching.
+// Code blocks, images, badges, HTML and URLs are dropped; link text is kept.
+func StripMarkdown(markdown string) string {
+	text := strings.ReplaceAll(markdown, "\r\n", "\n")
+
+	text = fencedCodePattern.ReplaceAllString(text, " ")
+	text = htmlCommentPattern.ReplaceAllString(text, " ")
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	text = imagePattern.ReplaceAllString(text, " ")
+	text = linkPattern.ReplaceAllString(text, "$1")
+	text = refLinkPattern.ReplaceAllString(text, " ")
+	text = inlineCodePattern.ReplaceAllString(text, "$1")
+	text = urlPattern.ReplaceAllString(text, " ")
+	text = headingPattern.ReplaceAllString(text, "")
+	text = listMarkerPattern.ReplaceAllString(text, "")
+	text = blockquotePattern.ReplaceAllString(text, "")
+	text = tableRulePattern.ReplaceAllString(text, " ")
+	text = strings.ReplaceAll(text, "|", " ")
+	text = emphasisPattern.ReplaceAllString(text, "")
+	text = whitespacePattern.ReplaceAllString(text, " ")
+
+	return strings.TrimSpace(text)
+}
+
+// Truncate shortens text to at most maxChars characters without splitting runes.
+// A non-positive maxChars disables truncation.
+func Truncate(text string, maxChars int) string {
+	if maxChars <= 0 {
+		return text
+	}
+
+	runes := []rune(text)
+	if len(runes) <= maxChars {
+		return text
+	}
+
+	return strings.TrimSpace(string(runes[:maxChars]))
+}
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-repo-insights/internal/models"
+)
+
+// anomalyMarker flags a repository that failed star-manipulation checks
+func anomalyMarker(checks []string) string {
+	if len(checks) == 0 {
+		return ""
+	}
+	return " ⚠"
+}
+
+// anomalyLegend explains the anomaly marker used in a table, if any
+func anomalyLegend(repos []models.TopRepoInfo) string {
+	for _, repo := range repos {
+		if len(repo.Anomalies) > 0 {
+			return "*⚠ failed star-anomaly screening; see Star Anomaly Screening for the evidence*"
+		}
+	}
+	return ""
+}
+
+// formatAnomalies generates the star anomaly screening section
+func (g *Generator) formatAnomalies(anomalies []models.AnomalyReport) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Star Anomaly Screening\n\n")
+	sb.WriteString("*Automated checks for star patterns typical of manipulation; a flag is a signal, not proof.*\n\n")
+
+	sb.WriteString("| Repository | Action | Evidence |\n")
+	sb.WriteString("|-----------|--------|----------|\n")
+
+	for _, anomaly := range anomalies {
+		action := "Annotated"
+		if anomaly.Excluded {
+			action = "Excluded"
+		}
+
+		evidence := make([]string, 0, len(anomaly.Flags))
+		for _, flag := range anomaly.Flags {
+			evidence = append(evidence, fmt.Sprintf("%s: %s", flag.Check, flag.Evidence))
+		}
+
+		sb.WriteString(fmt.Sprintf("| [%s](%s) | %s | %s |\n",
+			SanitizeRepoName(anomaly.RepoKey),
+			SanitizeURL(anomaly.URL),
+			action,
+			strings.Join(evidence, "; "),
+		))
+	}
+
+	return sb.String()
+}
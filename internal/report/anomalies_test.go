@@ -0,0 +1,60 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+func TestFormatAnomalies(t *testing.T) {
+	g := NewGenerator(config.Settings{}, config.KeywordConfig{})
+
+	tests := []struct {
+		name    string
+		anomaly models.AnomalyReport
+		row     string
+	}{
+		{
+			name: "annotated",
+			anomaly: models.AnomalyReport{
+				RepoKey: "o/burst", URL: "https://github.com/o/burst",
+				Flags: []models.AnomalyFlag{{Check: "burst", Evidence: "62% of stars within one hour"}},
+			},
+			row: "| [o/burst](https://github.com/o/burst) | Annotated | burst: 62% of stars within one hour |",
+		},
+		{
+			name: "excluded with several flags",
+			anomaly: models.AnomalyReport{
+				RepoKey: "o/farm", URL: "https://github.com/o/farm", Excluded: true,
+				Flags: []models.AnomalyFlag{
+					{Check: "accounts", Evidence: "48% new and empty accounts"},
+					{Check: "burst", Evidence: "70% of stars within one hour"},
+				},
+			},
+			row: "| [o/farm](https://github.com/o/farm) | Excluded | accounts: 48% new and empty accounts; burst: 70% of stars within one hour |",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := g.formatAnomalies([]models.AnomalyReport{tt.anomaly})
+			if !strings.HasPrefix(section, "## Star Anomaly Screening\n\n*Automated checks") {
+				t.Errorf("unexpected section start:\n%s", section)
+			}
+			if !strings.Contains(section, tt.row+"\n") {
+				t.Errorf("expected row %q in:\n%s", tt.row, section)
+			}
+		})
+	}
+}
+
+func TestAnomalyLegend(t *testing.T) {
+	if got := anomalyLegend([]models.TopRepoInfo{{RepoName: "clean"}}); got != "" {
+		t.Errorf("expected no legend without flagged repositories, got %q", got)
+	}
+	if got := anomalyLegend([]models.TopRepoInfo{{RepoName: "clean"}, {Anomalies: []string{"burst"}}}); !strings.HasPrefix(got, "*⚠") {
+		t.Errorf("expected anomaly legend, got %q", got)
+	}
+}
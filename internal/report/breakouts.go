@@ -0,0 +1,39 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-repo-insights/internal/models"
+)
+
+// formatBreakouts generates the projected breakouts section
+func (g *Generator) formatBreakouts(breakouts []models.BreakoutInfo, notes string) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Projected to Break Out\n\n")
+	sb.WriteString("*Projections from a trend fit to earlier snapshots, not measurements.*\n\n")
+	if notes != "" {
+		sb.WriteString(SanitizeMarkdown(notes))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("| Repository | Category | Heat_7 | Projected next 7d | Range | Daily growth |\n")
+	sb.WriteString("|-----------|----------|--------|-------------------|-------|--------------|\n")
+
+	for _, breakout := range breakouts {
+		forecast := breakout.Forecast
+		sb.WriteString(fmt.Sprintf("| [%s](%s) | %s | %s | ~%s | %s–%s | +%.0f%% |\n",
+			SanitizeRepoName(breakout.RepoName),
+			SanitizeURL(breakout.URL),
+			breakout.Category,
+			formatNumber(breakout.Heat7),
+			formatNumber(forecast.Stars),
+			formatNumber(forecast.Low),
+			formatNumber(forecast.High),
+			forecast.DailyGrowth*100,
+		))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+func TestFormatBreakouts(t *testing.T) {
+	g := NewGenerator(config.Settings{}, config.KeywordConfig{})
+	breakouts := []models.BreakoutInfo{
+		{
+			RepoName: "rising", URL: "https://github.com/o/rising", Category: "agent", Heat7: 2400,
+			Forecast: &models.Forecast{Stars: 5300, Low: 4500, High: 6200, DailyGrowth: 0.104},
+		},
+		{
+			RepoName: "steady", URL: "github.com/o/steady", Category: "rag", Heat7: 150,
+			Forecast: &models.Forecast{Stars: 180, Low: 150, High: 210, DailyGrowth: 0.02},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		notes string
+		want  []string
+	}{
+		{
+			name: "without notes",
+			want: []string{
+				"## Projected to Break Out\n\n*Projections from a trend fit to earlier snapshots, not measurements.*\n\n| Repository",
+				"| [rising](https://github.com/o/rising) | agent | 2,400 | ~5,300 | 4,500–6,200 | +10% |\n",
+				"| [steady](https://github.com/o/steady) | rag | 150 | ~180 | 150–210 | +2% |\n",
+			},
+		},
+		{
+			name:  "with notes",
+			notes: "Watch | these",
+			want:  []string{"not measurements.*\n\nWatch \\| these\n\n| Repository"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := g.formatBreakouts(breakouts, tt.notes)
+			for _, want := range tt.want {
+				if !strings.Contains(section, want) {
+					t.Errorf("expected %q in:\n%s", want, section)
+				}
+			}
+		})
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"ai-repo-insights/internal/config"
 	apperrors "ai-repo-insights/internal/errors"
+	"ai-repo-insights/internal/lifecycle"
 	"ai-repo-insights/internal/models"
 )
 
@@ -45,13 +47,34 @@ func (g *Generator) GenerateReport(
 	sb.WriteString("\n\n")
 
 	// Top N table
-	sb.WriteString(g.formatTopTable(summary.TopRepos, summary.Meta.TopN))
+	sb.WriteString(g.formatTopTable(summary.TopRepos, summary.Meta))
 	sb.WriteString("\n\n")
 
+	// Additional leaderboards
+	for _, board := range summary.Leaderboards {
+		if len(board.Repos) == 0 {
+			continue
+		}
+		sb.WriteString(g.formatLeaderboard(board, llmOutput.LeaderboardNotes[board.Metric]))
+		sb.WriteString("\n\n")
+	}
+
 	// Category breakdown
 	sb.WriteString(g.formatCategoryBreakdown(summary, llmOutput))
 	sb.WriteString("\n\n")
 
+	// Emerging themes
+	if len(summary.EmergingThemes) > 0 {
+		sb.WriteString(g.formatEmergingThemes(summary.EmergingThemes, llmOutput.EmergingThemesNotes))
+		sb.WriteString("\n\n")
+	}
+
+	// Projected breakouts
+	if len(summary.Breakouts) > 0 {
+		sb.WriteString(g.formatBreakouts(summary.Breakouts, llmOutput.BreakoutNotes))
+		sb.WriteString("\n\n")
+	}
+
 	// Dark horses
 	if len(summary.DarkHorses) > 0 {
 		sb.WriteString(g.formatDarkHorses(summary.DarkHorses, llmOutput.DarkHorseNotes))
@@ -64,6 +87,12 @@ func (g *Generator) GenerateReport(
 		sb.WriteString("\n\n")
 	}
 
+	// Star anomalies
+	if len(summary.Anomalies) > 0 {
+		sb.WriteString(g.formatAnomalies(summary.Anomalies))
+		sb.WriteString("\n\n")
+	}
+
 	// Highlights
 	if len(llmOutput.Highlights) > 0 {
 		sb.WriteString(g.formatHighlights(llmOutput.Highlights))
@@ -96,29 +125,78 @@ func (g *Generator) formatHeader(summary models.SummaryJSON, reportID string, la
 }
 
 // formatTopTable generates top N ranking table
-func (g *Generator) formatTopTable(repos []models.TopRepoInfo, topN int) string {
+func (g *Generator) formatTopTable(repos []models.TopRepoInfo, meta models.MetaInfo) string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("## Top %d Repositories\n\n", topN))
-	sb.WriteString("| Rank | Repository | Category | Language | Heat_7 | Heat_30 | Score |\n")
-	sb.WriteString("|------|-----------|----------|----------|---------|---------|-------|\n")
+	sb.WriteString(fmt.Sprintf("## Top %d Repositories\n\n", meta.TopN))
+	sb.WriteString("| Rank | Repository | Category | Language | Stage | Heat_7 | Heat_30 | Score |\n")
+	sb.WriteString("|------|-----------|----------|----------|-------|---------|---------|-------|\n")
 
 	for _, repo := range repos {
-		sb.WriteString(fmt.Sprintf("| %d | [%s](%s) | %s | %s | %s | %s | %s |\n",
+		stage := repo.Lifecycle
+		if stage == "" {
+			stage = "-"
+		}
+		sb.WriteString(fmt.Sprintf("| %d | [%s](%s)%s | %s | %s | %s | %s | %s | %s |\n",
 			repo.Rank,
-			SanitizeRepoName(repo.RepoName),
+			SanitizeRepoName(displayName(repo)),
 			SanitizeURL(repo.URL),
+			anomalyMarker(repo.Anomalies),
 			repo.Category,
 			repo.Language,
+			stage,
 			formatNumber(repo.Heat7),
 			formatNumber(repo.Heat30),
-			formatNumber(repo.Score),
+			formatNumber(repo.Score)+confidenceMarker(repo.Confidence),
 		))
 	}
 
+	if legend := confidenceLegend(repos); legend != "" {
+		sb.WriteString("\n" + legend + "\n")
+	}
+	if legend := anomalyLegend(repos); legend != "" {
+		sb.WriteString("\n" + legend + "\n")
+	}
+	if meta.Shortfall > 0 {
+		sb.WriteString(fmt.Sprintf("\n*%d of %d places left empty: no other candidate fit the selection constraints*\n",
+			meta.Shortfall, meta.TopN))
+	}
+
 	return sb.String()
 }
 
+// confidenceMarker flags a score that rests on estimated or missing star windows
+func confidenceMarker(confidence string) string {
+	switch confidence {
+	case models.ConfidenceMedium:
+		return " ~"
+	case models.ConfidenceLow:
+		return " ?"
+	}
+	return ""
+}
+
+// confidenceLegend explains the confidence markers used in a table, if any
+func confidenceLegend(repos []models.TopRepoInfo) string {
+	var estimated, missing bool
+	for _, repo := range repos {
+		estimated = estimated || repo.Confidence == models.ConfidenceMedium
+		missing = missing || repo.Confidence == models.ConfidenceLow
+	}
+
+	var notes []string
+	if estimated {
+		notes = append(notes, "~ some star windows estimated from earlier snapshots")
+	}
+	if missing {
+		notes = append(notes, "? missing from some trending windows, counted as 0 (low confidence)")
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return "*" + strings.Join(notes, "; ") + "*"
+}
+
 // formatCategoryBreakdown generates per-category sections
 func (g *Generator) formatCategoryBreakdown(summary models.SummaryJSON, llmOutput models.LLMOutput) string {
 	var sb strings.Builder
@@ -133,6 +211,10 @@ func (g *Generator) formatCategoryBreakdown(summary models.SummaryJSON, llmOutpu
 
 	// Format each category
 	for _, catStats := range summary.Categories {
+		// The Other bucket is always present in the summary; skip it when empty
+		if catStats.Count == 0 {
+			continue
+		}
 		sb.WriteString(fmt.Sprintf("### %s (%d projects)\n\n", catStats.Name, catStats.Count))
 
 		// LLM notes
@@ -143,15 +225,22 @@ func (g *Generator) formatCategoryBreakdown(summary models.SummaryJSON, llmOutpu
 
 		// Statistics
 		sb.WriteString(fmt.Sprintf("**Average Heat_7**: %s  \n", formatNumber(int(catStats.AvgHeat7))))
-		sb.WriteString(fmt.Sprintf("**Average Score**: %s  \n\n", formatNumber(int(catStats.AvgScore))))
+		sb.WriteString(fmt.Sprintf("**Average Score**: %s  \n", formatNumber(int(catStats.AvgScore))))
+		if stages := formatLifecycleCounts(catStats.Lifecycle); stages != "" {
+			sb.WriteString(fmt.Sprintf("**Lifecycle**: %s  \n", stages))
+		}
+		sb.WriteString("\n")
 
 		// List repos in this category
 		if repos, exists := reposByCategory[catStats.Name]; exists {
 			for _, repo := range repos {
 				sb.WriteString(fmt.Sprintf("- [%s](%s) - %s\n",
-					SanitizeRepoName(repo.RepoName),
+					SanitizeRepoName(displayName(repo)),
 					SanitizeURL(repo.URL),
 					SanitizeDescription(repo.Description)))
+				if repo.CuratorNote != "" {
+					sb.WriteString(fmt.Sprintf("  - *Curator note*: %s\n", SanitizeDescription(repo.CuratorNote)))
+				}
 			}
 			sb.WriteString("\n")
 		}
@@ -160,6 +249,54 @@ func (g *Generator) formatCategoryBreakdown(summary models.SummaryJSON, llmOutpu
 	return sb.String()
 }
 
+// formatLifecycleCounts lists stage counts in lifecycle order, e.g. "2 emerging, 1 cooling"
+func formatLifecycleCounts(counts map[string]int) string {
+	var parts []string
+	for _, stage := range lifecycle.Stages {
+		if counts[stage] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[stage], stage))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// displayName returns the curator-supplied display name, falling back to the repo name
+func displayName(repo models.TopRepoInfo) string {
+	if repo.DisplayName != "" {
+		return repo.DisplayName
+	}
+	return repo.RepoName
+}
+
+// formatEmergingThemes generates the emerging themes section
+func (g *Generator) formatEmergingThemes(themes []models.EmergingTheme, notes string) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Emerging Themes\n\n")
+	if notes != "" {
+		sb.WriteString(SanitizeMarkdown(notes))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("| Theme | Repositories | Avg Heat_7 | Closest Category |\n")
+	sb.WriteString("|-------|--------------|------------|------------------|\n")
+
+	for _, theme := range themes {
+		closest := "-"
+		if theme.DominantCategory != "" {
+			closest = fmt.Sprintf("%s (%.0f%%)", theme.DominantCategory, theme.CategoryShare*100)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			SanitizeMarkdown(theme.Label),
+			SanitizeDescription(strings.Join(theme.Repos, ", ")),
+			formatNumber(int(theme.AvgHeat7)),
+			closest,
+		))
+	}
+
+	return sb.String()
+}
+
 // formatDarkHorses generates dark horse section
 func (g *Generator) formatDarkHorses(darkHorses []models.DarkHorseInfo, notes string) string {
 	var sb strings.Builder
@@ -229,50 +366,84 @@ func (g *Generator) formatMethodology(meta models.MetaInfo) string {
 	includeKeywords := strings.Join(g.keywords.Include, ", ")
 	excludeKeywords := strings.Join(g.keywords.Exclude, ", ")
 
-	categoryList := make([]string, 0, len(g.keywords.Categories))
-	for cat := range g.keywords.Categories {
-		categoryList = append(categoryList, cat)
-	}
-	categories := strings.Join(categoryList, ", ")
+	categories := strings.Join(g.keywords.OrderedCategories(), ", ")
 
 	return fmt.Sprintf(`## Methodology
 
 **Data Sources**:
 - GitHub Trending pages
-- GitHub API stargazer timestamps
+- GitHub GraphQL API repository metadata (stars, topics, license, creation date)
 
 **Metrics**:
 - Heat_7: Stars gained in last 7 days
 - Heat_30: Stars gained in last 30 days
 - Score: Weighted scoring combining short-term heat and sustained growth
-  - Formula: 0.6 × stars_1d + 0.3 × (stars_7d / 7) + 0.1 × (stars_30d / 30)
-  - Emphasizes recent activity (60%%) while considering sustained trends (40%%)
+  - Formula: %s × stars_1d + %s × (stars_7d / 7) + %s × (stars_30d / 30)
 
 **Filtering**:
 - Include keywords: %s
 - Exclude keywords: %s
 - Categories: %s
 
+**Lifecycle stages** (from stars today vs. the weekly average, week-over-week change and top-list streak):
+- Emerging: new to the top list
+- Accelerating: weekly stars up 25%%+ or today's pace 1.5x the weekly average
+- Peaking: on a plateau, or grew over the week but slowing today
+- Cooling: weekly stars down 25%%+ or today's pace under half the weekly average
+- Evergreen: steady pace over 4+ consecutive reports
+
 **Ranking**:
 1. Sort by Heat_30 (descending)
 2. Tie-break by Score (descending)
-3. Select top %d
+3. Select top %d%s
 
 **Limitations**:
 - Trending data limited to GitHub's trending algorithm
 - Star history may be incomplete for repos with >40k stars (API pagination limits)
 - LLM-generated commentary is interpretive, not prescriptive
-- Weekly snapshots may miss short-lived trends`,
-		meta.WindowDays,
-		meta.ShortWindowDays,
-		meta.ShortWindowDays,
+- Weekly snapshots may miss short-lived trends
+- Repositories absent from a trending window count 0 stars for it unless estimated from earlier snapshots`,
+		formatWeight(g.settings.ScoreWeights.Today),
+		formatWeight(g.settings.ScoreWeights.Week),
+		formatWeight(g.settings.ScoreWeights.Month),
 		includeKeywords,
 		excludeKeywords,
 		categories,
 		meta.TopN,
+		g.formatSelectionConstraints(),
 	)
 }
 
+// formatWeight formats a score weight without trailing zeros
+func formatWeight(weight float64) string {
+	return strconv.FormatFloat(weight, 'f', -1, 64)
+}
+
+// formatSelectionConstraints lists the diversity constraints applied to the top N
+func (g *Generator) formatSelectionConstraints() string {
+	var constraints []string
+	if g.settings.MaxPerCategory > 0 {
+		constraints = append(constraints, fmt.Sprintf("at most %d per category", g.settings.MaxPerCategory))
+	}
+	if g.settings.MaxPerOwner > 0 {
+		constraints = append(constraints, fmt.Sprintf("at most %d per owner", g.settings.MaxPerOwner))
+	}
+	if g.settings.MinPerCategory > 0 {
+		constraints = append(constraints, fmt.Sprintf("at least %d per category", g.settings.MinPerCategory))
+	}
+	if g.settings.MinPerLanguage > 0 {
+		constraints = append(constraints, fmt.Sprintf("at least %d per language", g.settings.MinPerLanguage))
+	}
+	if len(constraints) == 0 {
+		return ""
+	}
+
+	return "\n4. Diversity constraints: " + strings.Join(constraints, ", ") +
+		". Minimums are filled first with the best-ranked repositories of each group," +
+		" then the rest in rank order, skipping repositories over a cap; pinned repositories" +
+		" ignore caps. The table keeps rank order; places no candidate could fill are noted under it."
+}
+
 // SaveReport saves report to reports/{report_id}.md
 func (g *Generator) SaveReport(content string, reportID string) error {
 	reportsDir := "reports"
@@ -0,0 +1,191 @@
+package report
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/importer"
+	"ai-repo-insights/internal/lifecycle"
+	"ai-repo-insights/internal/models"
+)
+
+func TestFormatTopTable(t *testing.T) {
+	g := NewGenerator(config.Settings{}, config.KeywordConfig{})
+
+	tests := []struct {
+		name     string
+		repo     models.TopRepoInfo
+		row      string
+		legends  []string
+		excluded []string
+	}{
+		{
+			name:     "observed score without stage",
+			repo:     models.TopRepoInfo{Rank: 1, RepoName: "orca", URL: "https://github.com/stablyai/orca", Category: "agent", Language: "typescript", Heat7: 5652, Heat30: 16894, Score: 298},
+			row:      "| 1 | [orca](https://github.com/stablyai/orca) | agent | typescript | - | 5,652 | 16,894 | 298 |",
+			excluded: []string{"~ some star windows", "? missing from", "⚠ failed"},
+		},
+		{
+			name:    "estimated windows",
+			repo:    models.TopRepoInfo{Rank: 2, RepoName: "r", URL: "https://github.com/o/r", Lifecycle: lifecycle.Emerging, Score: 1200, Confidence: models.ConfidenceMedium},
+			row:     "| 2 | [r](https://github.com/o/r) |  |  | emerging | 0 | 0 | 1,200 ~ |",
+			legends: []string{"*~ some star windows estimated from earlier snapshots*"},
+		},
+		{
+			name:    "missing windows",
+			repo:    models.TopRepoInfo{Rank: 3, RepoName: "r", URL: "https://github.com/o/r", Lifecycle: lifecycle.Cooling, Score: 40, Confidence: models.ConfidenceLow},
+			row:     "| 3 | [r](https://github.com/o/r) |  |  | cooling | 0 | 0 | 40 ? |",
+			legends: []string{"*? missing from some trending windows, counted as 0 (low confidence)*"},
+		},
+		{
+			name:    "display name and anomaly",
+			repo:    models.TopRepoInfo{Rank: 4, RepoName: "r", DisplayName: "Studio (beta)", URL: "github.com/o/r", Lifecycle: lifecycle.Evergreen, Anomalies: []string{"burst"}},
+			row:     "| 4 | [Studio beta](https://github.com/o/r) ⚠ |  |  | evergreen | 0 | 0 | 0 |",
+			legends: []string{"*⚠ failed star-anomaly screening; see Star Anomaly Screening for the evidence*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := g.formatTopTable([]models.TopRepoInfo{tt.repo}, models.MetaInfo{TopN: 10})
+			if !strings.Contains(table, "| Rank | Repository | Category | Language | Stage | Heat_7 | Heat_30 | Score |\n") {
+				t.Errorf("missing header with Stage column:\n%s", table)
+			}
+			if !strings.Contains(table, tt.row+"\n") {
+				t.Errorf("expected row %q in:\n%s", tt.row, table)
+			}
+			for _, legend := range tt.legends {
+				if !strings.Contains(table, legend) {
+					t.Errorf("expected legend %q in:\n%s", legend, table)
+				}
+			}
+			for _, text := range tt.excluded {
+				if strings.Contains(table, text) {
+					t.Errorf("expected no %q in:\n%s", text, table)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatTopTable_Shortfall(t *testing.T) {
+	g := NewGenerator(config.Settings{}, config.KeywordConfig{})
+	repos := []models.TopRepoInfo{{Rank: 1, RepoName: "r", URL: "https://github.com/o/r"}}
+
+	table := g.formatTopTable(repos, models.MetaInfo{TopN: 3, Shortfall: 2})
+	note := "*2 of 3 places left empty: no other candidate fit the selection constraints*"
+	if !strings.Contains(table, note) {
+		t.Errorf("expected note %q in:\n%s", note, table)
+	}
+
+	table = g.formatTopTable(repos, models.MetaInfo{TopN: 1})
+	if strings.Contains(table, "places left empty") {
+		t.Errorf("expected no shortfall note in a full table:\n%s", table)
+	}
+}
+
+func TestConfidenceLegend(t *testing.T) {
+	repos := []models.TopRepoInfo{
+		{Confidence: models.ConfidenceLow},
+		{Confidence: models.ConfidenceHigh},
+		{Confidence: models.ConfidenceMedium},
+	}
+	want := "*~ some star windows estimated from earlier snapshots; ? missing from some trending windows, counted as 0 (low confidence)*"
+	if got := confidenceLegend(repos); got != want {
+		t.Errorf("confidenceLegend() = %q, want %q", got, want)
+	}
+	if got := confidenceLegend(repos[1:2]); got != "" {
+		t.Errorf("expected no legend for high confidence, got %q", got)
+	}
+}
+
+func TestFormatLifecycleCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		counts   map[string]int
+		expected string
+	}{
+		{"empty", nil, ""},
+		{"lifecycle order", map[string]int{lifecycle.Cooling: 1, lifecycle.Emerging: 2}, "2 emerging, 1 cooling"},
+		{"zero counts left out", map[string]int{lifecycle.Peaking: 0, lifecycle.Evergreen: 3}, "3 evergreen"},
+		{"unknown stages ignored", map[string]int{"breakout": 4, lifecycle.Accelerating: 1}, "1 accelerating"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatLifecycleCounts(tt.counts); got != tt.expected {
+				t.Errorf("formatLifecycleCounts() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatSelectionConstraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings config.Settings
+		expected string
+	}{
+		{"no constraints", config.Settings{}, ""},
+		{"caps", config.Settings{MaxPerCategory: 3, MaxPerOwner: 1}, "4. Diversity constraints: at most 3 per category, at most 1 per owner."},
+		{"minimums", config.Settings{MinPerCategory: 2, MinPerLanguage: 1}, "4. Diversity constraints: at least 2 per category, at least 1 per language."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewGenerator(tt.settings, config.KeywordConfig{}).formatSelectionConstraints()
+			if tt.expected == "" {
+				if got != "" {
+					t.Errorf("expected no constraints, got %q", got)
+				}
+				return
+			}
+			if !strings.HasPrefix(got, "\n"+tt.expected) {
+				t.Errorf("formatSelectionConstraints() = %q, want prefix %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateReport_RoundTrip(t *testing.T) {
+	summary := models.SummaryJSON{
+		Meta: models.MetaInfo{RunDate: "2026-07-20", WindowDays: 30, TopN: 3, FilterDomain: "AI"},
+		TopRepos: []models.TopRepoInfo{
+			{Rank: 1, RepoKey: "calesthio/OpenMontage", RepoName: "OpenMontage", DisplayName: "OpenMontage Studio", URL: "https://github.com/calesthio/OpenMontage", Category: "agent", Language: "python", Heat7: 1234, Heat30: 34396, Score: 114, Confidence: models.ConfidenceMedium, Lifecycle: lifecycle.Accelerating, Anomalies: []string{"burst"}},
+			{Rank: 2, RepoKey: "stablyai/orca", RepoName: "orca", URL: "https://github.com/stablyai/orca", Category: "rag", Language: "typescript", Heat7: 5652, Heat30: 16894, Score: 298},
+			{Rank: 3, RepoKey: "o/quiet", RepoName: "quiet", URL: "https://github.com/o/quiet", Category: config.OtherCategory, Language: "go", Heat7: 12, Heat30: 1001, Score: 7, Confidence: models.ConfidenceLow, Lifecycle: lifecycle.Cooling},
+		},
+		Repeaters: []models.RepeaterInfo{
+			{RepoKey: "stablyai/orca", RepoName: "orca", URL: "https://github.com/stablyai/orca", WeeksInTop: 3, Category: "rag", CurrentHeat7: 5652},
+		},
+	}
+
+	markdown := NewGenerator(config.Settings{}, config.KeywordConfig{}).GenerateReport(summary, models.LLMOutput{Intro: "Intro", RepeatersNotes: "Back again."}, "2026-07-week30", []string{"python"})
+	parsed, err := importer.Parse(markdown)
+	if err != nil {
+		t.Fatalf("Parse() error = %v\n%s", err, markdown)
+	}
+
+	if parsed.ReportID != "2026-07-week30" {
+		t.Errorf("ReportID = %q", parsed.ReportID)
+	}
+	if parsed.Summary.Meta != summary.Meta {
+		t.Errorf("Meta = %+v, want %+v", parsed.Summary.Meta, summary.Meta)
+	}
+
+	// Confidence and anomaly markers are display only; everything else survives
+	want := make([]models.TopRepoInfo, len(summary.TopRepos))
+	for i, repo := range summary.TopRepos {
+		repo.Confidence = ""
+		repo.Anomalies = nil
+		want[i] = repo
+	}
+	if !reflect.DeepEqual(parsed.Summary.TopRepos, want) {
+		t.Errorf("TopRepos = %+v\nwant %+v", parsed.Summary.TopRepos, want)
+	}
+	if !reflect.DeepEqual(parsed.Summary.Repeaters, summary.Repeaters) {
+		t.Errorf("Repeaters = %+v, want %+v", parsed.Summary.Repeaters, summary.Repeaters)
+	}
+}
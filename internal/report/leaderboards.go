@@ -0,0 +1,75 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+// leaderboardColumn is one metric column of a leaderboard table
+type leaderboardColumn struct {
+	header string
+	value  func(models.LeaderboardEntry) string
+}
+
+var (
+	starsTodayColumn = leaderboardColumn{"Stars Today", func(e models.LeaderboardEntry) string { return formatNumber(e.StarsToday) }}
+	heat7Column      = leaderboardColumn{"Heat_7", func(e models.LeaderboardEntry) string { return formatNumber(e.Heat7) }}
+	heat30Column     = leaderboardColumn{"Heat_30", func(e models.LeaderboardEntry) string { return formatNumber(e.Heat30) }}
+	scoreColumn      = leaderboardColumn{"Score", func(e models.LeaderboardEntry) string { return formatNumber(e.Score) }}
+	totalStarsColumn = leaderboardColumn{"Total Stars", func(e models.LeaderboardEntry) string { return formatNumber(e.TotalStars) }}
+	growthColumn     = leaderboardColumn{"Growth (7d)", func(e models.LeaderboardEntry) string {
+		return fmt.Sprintf("+%.0f%%", e.RelativeGrowth*100)
+	}}
+)
+
+// leaderboardColumns lists the metric columns of each leaderboard, ranking metric first
+var leaderboardColumns = map[string][]leaderboardColumn{
+	config.LeaderboardStarsToday:     {starsTodayColumn, heat7Column, totalStarsColumn},
+	config.LeaderboardHeat7:          {heat7Column, starsTodayColumn, heat30Column},
+	config.LeaderboardScore:          {scoreColumn, heat7Column, heat30Column},
+	config.LeaderboardRelativeGrowth: {growthColumn, heat7Column, totalStarsColumn},
+}
+
+// formatLeaderboard generates the section of one additional leaderboard
+func (g *Generator) formatLeaderboard(board models.Leaderboard, notes string) string {
+	var sb strings.Builder
+	columns := leaderboardColumns[board.Metric]
+
+	sb.WriteString(fmt.Sprintf("## %s\n\n", SanitizeMarkdown(board.Title)))
+	if notes != "" {
+		sb.WriteString(SanitizeMarkdown(notes))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("| Rank | Repository | Category |")
+	for _, column := range columns {
+		sb.WriteString(" " + column.header + " |")
+	}
+	sb.WriteString("\n|------|-----------|----------|")
+	for _, column := range columns {
+		sb.WriteString(strings.Repeat("-", len(column.header)+2) + "|")
+	}
+	sb.WriteString("\n")
+
+	for _, entry := range board.Repos {
+		name := entry.RepoName
+		if entry.DisplayName != "" {
+			name = entry.DisplayName
+		}
+		sb.WriteString(fmt.Sprintf("| %d | [%s](%s) | %s |",
+			entry.Rank,
+			SanitizeRepoName(name),
+			SanitizeURL(entry.URL),
+			entry.Category,
+		))
+		for _, column := range columns {
+			sb.WriteString(" " + column.value(entry) + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
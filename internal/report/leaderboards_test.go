@@ -0,0 +1,73 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+func TestFormatLeaderboard(t *testing.T) {
+	g := NewGenerator(config.Settings{}, config.KeywordConfig{})
+	entry := models.LeaderboardEntry{
+		Rank: 1, RepoName: "orca", URL: "https://github.com/stablyai/orca", Category: "agent",
+		StarsToday: 321, Heat7: 5652, Heat30: 16894, Score: 298, TotalStars: 12000, RelativeGrowth: 0.875,
+	}
+
+	tests := []struct {
+		metric string
+		header string
+		row    string
+	}{
+		{
+			metric: config.LeaderboardStarsToday,
+			header: "| Rank | Repository | Category | Stars Today | Heat_7 | Total Stars |\n|------|-----------|----------|-------------|--------|-------------|",
+			row:    "| 1 | [orca](https://github.com/stablyai/orca) | agent | 321 | 5,652 | 12,000 |",
+		},
+		{
+			metric: config.LeaderboardHeat7,
+			header: "| Rank | Repository | Category | Heat_7 | Stars Today | Heat_30 |",
+			row:    "| 1 | [orca](https://github.com/stablyai/orca) | agent | 5,652 | 321 | 16,894 |",
+		},
+		{
+			metric: config.LeaderboardScore,
+			header: "| Rank | Repository | Category | Score | Heat_7 | Heat_30 |",
+			row:    "| 1 | [orca](https://github.com/stablyai/orca) | agent | 298 | 5,652 | 16,894 |",
+		},
+		{
+			metric: config.LeaderboardRelativeGrowth,
+			header: "| Rank | Repository | Category | Growth (7d) | Heat_7 | Total Stars |",
+			row:    "| 1 | [orca](https://github.com/stablyai/orca) | agent | +88% | 5,652 | 12,000 |",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metric, func(t *testing.T) {
+			board := models.Leaderboard{Metric: tt.metric, Title: "Board | " + tt.metric, Repos: []models.LeaderboardEntry{entry}}
+			section := g.formatLeaderboard(board, "Notes")
+
+			if !strings.HasPrefix(section, "## Board \\| "+tt.metric+"\n\nNotes\n\n") {
+				t.Errorf("expected sanitized title and notes, got:\n%s", section)
+			}
+			for _, want := range []string{tt.header, tt.row} {
+				if !strings.Contains(section, want+"\n") {
+					t.Errorf("expected %q in:\n%s", want, section)
+				}
+			}
+		})
+	}
+
+	t.Run("display name without notes", func(t *testing.T) {
+		named := entry
+		named.DisplayName = "Orca IDE"
+		section := g.formatLeaderboard(models.Leaderboard{Metric: config.LeaderboardScore, Title: "Top Scores", Repos: []models.LeaderboardEntry{named}}, "")
+
+		if !strings.HasPrefix(section, "## Top Scores\n\n| Rank") {
+			t.Errorf("expected table right after the title, got:\n%s", section)
+		}
+		if !strings.Contains(section, "[Orca IDE](https://github.com/stablyai/orca)") {
+			t.Errorf("expected display name, got:\n%s", section)
+		}
+	})
+}
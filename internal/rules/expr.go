@@ -0,0 +1,146 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Term is a single keyword or quoted phrase, optionally restricted to one field
+type Term struct {
+	Field  Field
+	Text   string
+	Phrase bool
+}
+
+// String renders the term in rule syntax
+func (t Term) String() string {
+	text := t.Text
+	if t.Phrase {
+		text = fmt.Sprintf("%q", t.Text)
+	}
+	if t.Field != FieldAny {
+		return string(t.Field) + ":" + text
+	}
+	return text
+}
+
+// Matcher decides whether a single term matches the repository being evaluated
+type Matcher interface {
+	MatchTerm(term Term) bool
+}
+
+// Expr is a compiled rule expression
+type Expr interface {
+	// Eval evaluates the expression against a repository matcher
+	Eval(m Matcher) bool
+	// Terms returns the terms that appear in the expression
+	Terms() []Term
+	// String renders the expression in canonical rule syntax
+	String() string
+}
+
+// termExpr matches a single term
+type termExpr struct {
+	term Term
+}
+
+func (e *termExpr) Eval(m Matcher) bool {
+	return m.MatchTerm(e.term)
+}
+
+func (e *termExpr) Terms() []Term {
+	return []Term{e.term}
+}
+
+func (e *termExpr) String() string {
+	return e.term.String()
+}
+
+// andExpr matches when every operand matches
+type andExpr struct {
+	operands []Expr
+}
+
+func (e *andExpr) Eval(m Matcher) bool {
+	for _, operand := range e.operands {
+		if !operand.Eval(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *andExpr) Terms() []Term {
+	return collectTerms(e.operands)
+}
+
+func (e *andExpr) String() string {
+	return joinOperands(e.operands, " AND ")
+}
+
+// orExpr matches when any operand matches
+type orExpr struct {
+	operands []Expr
+}
+
+func (e *orExpr) Eval(m Matcher) bool {
+	for _, operand := range e.operands {
+		if operand.Eval(m) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *orExpr) Terms() []Term {
+	return collectTerms(e.operands)
+}
+
+func (e *orExpr) String() string {
+	return joinOperands(e.operands, " OR ")
+}
+
+// notExpr negates its operand
+type notExpr struct {
+	operand Expr
+}
+
+func (e *notExpr) Eval(m Matcher) bool {
+	return !e.operand.Eval(m)
+}
+
+func (e *notExpr) Terms() []Term {
+	return e.operand.Terms()
+}
+
+func (e *notExpr) String() string {
+	return "NOT " + wrap(e.operand)
+}
+
+// collectTerms concatenates the terms of all operands
+func collectTerms(operands []Expr) []Term {
+	var terms []Term
+	for _, operand := range operands {
+		terms = append(terms, operand.Terms()...)
+	}
+	return terms
+}
+
+// joinOperands renders operands joined by an operator, parenthesizing compound operands
+func joinOperands(operands []Expr, operator string) string {
+	parts := make([]string, len(operands))
+	for i, operand := range operands {
+		parts[i] = wrap(operand)
+	}
+	return strings.Join(parts, operator)
+}
+
+// wrap parenthesizes compound expressions
+func wrap(e Expr) string {
+	switch e.(type) {
+	case *andExpr, *orExpr:
+		return "(" + e.String() + ")"
+	default:
+		return e.String()
+	}
+}
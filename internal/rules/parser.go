@@ -0,0 +1,311 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Field identifies which part of a repository a term is matched against
+type Field string
+
+const (
+	// FieldAny matches name, description and topics together
+	FieldAny         Field = ""
+	FieldName        Field = "name"
+	FieldDescription Field = "desc"
+	FieldTopic       Field = "topic"
+	FieldReadme      Field = "readme"
+)
+
+// fieldAliases maps accepted qualifier spellings to fields
+var fieldAliases = map[string]Field{
+	"name":        FieldName,
+	"desc":        FieldDescription,
+	"description": FieldDescription,
+	"topic":       FieldTopic,
+	"topics":      FieldTopic,
+	"readme":      FieldReadme,
+}
+
+// SyntaxError reports a rule expression parse failure at a 1-based character position
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+// Error implements the error interface
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenTerm
+	tokenPhrase
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+// token is a lexical unit of a rule expression
+type token struct {
+	kind  tokenKind
+	text  string
+	field Field
+	pos   int
+}
+
+// describe returns a human-readable token description for error messages
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenAnd:
+		return "AND"
+	case tokenOr:
+		return "OR"
+	case tokenNot:
+		return "NOT"
+	case tokenLParen:
+		return "'('"
+	case tokenRParen:
+		return "')'"
+	case tokenPhrase:
+		return fmt.Sprintf("phrase %q", t.text)
+	default:
+		return fmt.Sprintf("term %q", t.text)
+	}
+}
+
+// Parse parses a rule expression such as `agent AND NOT (game OR topic:minecraft)`.
+// Adjacent terms without an operator are combined with AND.
+func Parse(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, &SyntaxError{Pos: 1, Msg: "empty expression"}
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: next.pos, Msg: fmt.Sprintf("unexpected %s", next.describe())}
+	}
+
+	return expr, nil
+}
+
+// lex splits the input into tokens
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, pos: pos})
+			i++
+		case r == '"':
+			text, next, err := lexPhrase(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenPhrase, text: text, pos: pos})
+			i = next
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			word := string(runes[start:i])
+
+			// Only upper-case operators, so "and", "or" and "not" stay usable as terms
+			switch word {
+			case "AND":
+				tokens = append(tokens, token{kind: tokenAnd, pos: pos})
+				continue
+			case "OR":
+				tokens = append(tokens, token{kind: tokenOr, pos: pos})
+				continue
+			case "NOT":
+				tokens = append(tokens, token{kind: tokenNot, pos: pos})
+				continue
+			}
+
+			// A colon only qualifies a field when it follows a known field name, so
+			// keywords such as "llama:7b" are plain terms
+			colon := strings.Index(word, ":")
+			field, ok := Field(""), false
+			if colon >= 0 {
+				field, ok = fieldAliases[strings.ToLower(word[:colon])]
+			}
+			if !ok {
+				tokens = append(tokens, token{kind: tokenTerm, text: strings.ToLower(word), pos: pos})
+				continue
+			}
+			qualifier := strings.ToLower(word[:colon])
+
+			value := word[colon+1:]
+			switch {
+			case value != "":
+				tokens = append(tokens, token{kind: tokenTerm, text: strings.ToLower(value), field: field, pos: pos})
+			case i < len(runes) && runes[i] == '"':
+				text, next, err := lexPhrase(runes, i)
+				if err != nil {
+					return nil, err
+				}
+				tokens = append(tokens, token{kind: tokenPhrase, text: text, field: field, pos: pos})
+				i = next
+			default:
+				return nil, &SyntaxError{Pos: pos, Msg: fmt.Sprintf("missing value after %q", qualifier+":")}
+			}
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(runes) + 1})
+	return tokens, nil
+}
+
+// lexPhrase reads a quoted phrase starting at the opening quote and returns the text and next index
+func lexPhrase(runes []rune, start int) (string, int, error) {
+	end := start + 1
+	for end < len(runes) && runes[end] != '"' {
+		end++
+	}
+	if end >= len(runes) {
+		return "", 0, &SyntaxError{Pos: start + 1, Msg: "unterminated quoted phrase"}
+	}
+
+	text := strings.ToLower(strings.Join(strings.Fields(string(runes[start+1:end])), " "))
+	if text == "" {
+		return "", 0, &SyntaxError{Pos: start + 1, Msg: "empty quoted phrase"}
+	}
+
+	return text, end + 1, nil
+}
+
+// parser is a recursive-descent parser over lexed tokens
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// parseOr parses: and (OR and)*
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Expr{left}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &orExpr{operands: operands}, nil
+}
+
+// parseAnd parses: unary ((AND)? unary)*
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Expr{left}
+	for {
+		switch p.peek().kind {
+		case tokenAnd:
+			p.next()
+		case tokenTerm, tokenPhrase, tokenNot, tokenLParen:
+			// Implicit AND between adjacent operands
+		default:
+			if len(operands) == 1 {
+				return left, nil
+			}
+			return &andExpr{operands: operands}, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+}
+
+// parseUnary parses: NOT unary | primary
+func (p *parser) parseUnary() (Expr, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses: '(' or ')' | term | phrase
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, &SyntaxError{Pos: closing.pos, Msg: fmt.Sprintf("expected ')' to close '(' at position %d, found %s", t.pos, closing.describe())}
+		}
+		return expr, nil
+	case tokenTerm:
+		return &termExpr{term: Term{Field: t.field, Text: t.text}}, nil
+	case tokenPhrase:
+		return &termExpr{term: Term{Field: t.field, Text: t.text, Phrase: true}}, nil
+	default:
+		return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("expected term, phrase or '(', found %s", t.describe())}
+	}
+}
@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeMatcher matches terms from a fixed set keyed by "field:text"
+type fakeMatcher map[string]bool
+
+func (m fakeMatcher) MatchTerm(term Term) bool {
+	return m[string(term.Field)+":"+term.Text]
+}
+
+func TestParse_Canonical(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"agent", "agent"},
+		{"agent AND NOT game", "agent AND NOT game"},
+		{"agent NOT game", "agent AND NOT game"},
+		{"rag OR (vector AND database)", "rag OR (vector AND database)"},
+		{"rag or vector and database", "rag AND or AND vector AND and AND database"},
+		{`"large language model" OR llm`, `"large language model" OR llm`},
+		{`topic:llm AND name:Agent`, "topic:llm AND name:agent"},
+		{`desc:"vector  database"`, `desc:"vector database"`},
+		{"NOT (a OR b)", "NOT (a OR b)"},
+		{"a AND NOT b OR c", "(a AND NOT b) OR c"},
+		{"llama:7b OR c++:20", "llama:7b OR c++:20"},
+		{"Lang:Go", "lang:go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			if got := expr.String(); got != tt.expected {
+				t.Errorf("Parse(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		input       string
+		pos         int
+		msgContains string
+	}{
+		{"", 1, "empty expression"},
+		{"agent AND", 10, "expected term"},
+		{"(agent OR rag", 14, "expected ')'"},
+		{"agent )", 7, "unexpected ')'"},
+		{`"vector database`, 1, "unterminated"},
+		{"topic: llm", 1, "missing value"},
+		{"OR agent", 1, "expected term"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if err == nil {
+				t.Fatalf("expected error for %q", tt.input)
+			}
+			syntaxErr, ok := err.(*SyntaxError)
+			if !ok {
+				t.Fatalf("expected *SyntaxError, got %T", err)
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("expected position %d, got %d (%v)", tt.pos, syntaxErr.Pos, err)
+			}
+			if !strings.Contains(syntaxErr.Msg, tt.msgContains) {
+				t.Errorf("expected message containing %q, got %q", tt.msgContains, syntaxErr.Msg)
+			}
+		})
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		matches  fakeMatcher
+		expected bool
+	}{
+		{"term matches", "agent", fakeMatcher{":agent": true}, true},
+		{"and requires all", "agent AND game", fakeMatcher{":agent": true}, false},
+		{"and not excludes", "agent AND NOT game", fakeMatcher{":agent": true, ":game": true}, false},
+		{"and not passes", "agent AND NOT game", fakeMatcher{":agent": true}, true},
+		{"or with group", "rag OR (vector AND database)", fakeMatcher{":vector": true, ":database": true}, true},
+		{"or with partial group", "rag OR (vector AND database)", fakeMatcher{":vector": true}, false},
+		{"field qualifier", "topic:llm", fakeMatcher{":llm": true}, false},
+		{"field qualifier matches field", "topic:llm", fakeMatcher{"topic:llm": true}, true},
+		{"phrase", `"vector database"`, fakeMatcher{":vector database": true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+			}
+			if got := expr.Eval(tt.matches); got != tt.expected {
+				t.Errorf("Eval(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTerms(t *testing.T) {
+	expr, err := Parse(`agent AND NOT (game OR topic:"video game")`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	terms := expr.Terms()
+	if len(terms) != 3 {
+		t.Fatalf("expected 3 terms, got %d: %v", len(terms), terms)
+	}
+	if terms[2].Field != FieldTopic || !terms[2].Phrase || terms[2].Text != "video game" {
+		t.Errorf("unexpected third term: %+v", terms[2])
+	}
+}
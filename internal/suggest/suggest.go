@@ -0,0 +1,294 @@
+package suggest
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"ai-repo-insights/internal/classifier"
+	"ai-repo-insights/internal/evaluation"
+	"ai-repo-insights/internal/models"
+	"ai-repo-insights/internal/textutil"
+)
+
+// Suggestion targets
+const (
+	TargetInclude = "include"
+	TargetExclude = "exclude"
+	// TargetNewCategory marks terms common in uncategorized repos that no existing category shares
+	TargetNewCategory = "new-category"
+)
+
+// maxExamples caps the example repositories listed per suggestion
+const maxExamples = 3
+
+// Options tunes which terms are proposed
+type Options struct {
+	// MinSupport is the minimum number of target repositories containing a term
+	MinSupport int
+	// MinLift is the minimum ratio of a term's frequency in the target group to the reference group
+	MinLift float64
+	// Limit caps the suggestions per target; 0 means no limit
+	Limit int
+}
+
+// DefaultOptions returns the options used by the suggest-keywords command
+func DefaultOptions() Options {
+	return Options{MinSupport: 2, MinLift: 2, Limit: 10}
+}
+
+// Suggestion is one proposed keyword
+type Suggestion struct {
+	Term string `json:"term"`
+	// Target is "include", "exclude", a category name or "new-category"
+	Target   string   `json:"target"`
+	Support  int      `json:"support"`
+	Lift     float64  `json:"lift"`
+	Score    float64  `json:"score"`
+	Examples []string `json:"examples"`
+}
+
+// Patch lists keyword additions in the shape of keywords.json
+type Patch struct {
+	Include    []string            `json:"include,omitempty"`
+	Exclude    []string            `json:"exclude,omitempty"`
+	Categories map[string][]string `json:"categories,omitempty"`
+}
+
+// GroupCounts reports how the mined repositories were classified
+type GroupCounts struct {
+	Categorized   int `json:"categorized"`
+	Uncategorized int `json:"uncategorized"`
+	Rejected      int `json:"rejected"`
+	Excluded      int `json:"excluded"`
+	// Labeled repositories the classifier gets wrong
+	FalsePositives int `json:"false_positives"`
+	FalseNegatives int `json:"false_negatives"`
+}
+
+// Result holds all suggestions and the patch built from them
+type Result struct {
+	Counts      GroupCounts  `json:"counts"`
+	Suggestions []Suggestion `json:"suggestions"`
+	Patch       Patch        `json:"patch"`
+}
+
+// document is the set of candidate terms of one repository
+type document struct {
+	key      string
+	category string
+	terms    map[string]bool
+}
+
+// Suggest mines repositories for keyword candidates:
+//   - include: terms that included repos and labeled positives share, over-represented
+//     there versus rejected repos, proposed when they would bring in at least one
+//     rejected repo or labeled false negative (the examples)
+//   - exclude: terms over-represented in false positives (labeled negatives that the
+//     classifier includes) versus included repos; none are proposed without labels
+//   - categories: terms over-represented in uncategorized repos versus all other repos,
+//     proposed for the category whose repositories use them most
+//
+// Terms are description words, adjacent word pairs and topics. Each is scored by
+// support × log(1 + lift), where support counts target repos containing the term and
+// lift compares its document frequency in the target and reference groups.
+// Terms that configured keywords already match are skipped. labeled may be empty;
+// labels take precedence over a snapshot record of the same repository.
+func Suggest(repoClassifier *classifier.Classifier, repos []models.RepoMetadata, labeled []evaluation.LabeledRepo, opts Options) Result {
+	var categorized, uncategorized, rejected, excluded []document
+	var truePositives, falsePositives, falseNegatives []document
+	surface := make(map[string]string)
+
+	isLabeled := make(map[string]bool, len(labeled))
+	for _, example := range labeled {
+		repo := example.Metadata
+		isLabeled[strings.ToLower(repo.Key())] = true
+
+		trace := repoClassifier.Explain(repo)
+		doc := document{key: repo.Key(), category: trace.PrimaryCategory, terms: extractTerms(repo, surface)}
+		switch {
+		case example.Include && trace.Included:
+			truePositives = append(truePositives, doc)
+		case example.Include:
+			falseNegatives = append(falseNegatives, doc)
+		case trace.Included:
+			falsePositives = append(falsePositives, doc)
+		}
+	}
+
+	sorted := append([]models.RepoMetadata{}, repos...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Key() < sorted[j].Key() })
+
+	for _, repo := range sorted {
+		if isLabeled[strings.ToLower(repo.Key())] {
+			continue
+		}
+
+		trace := repoClassifier.Explain(repo)
+		doc := document{key: repo.Key(), category: trace.PrimaryCategory, terms: extractTerms(repo, surface)}
+
+		switch {
+		case trace.Included && trace.PrimaryCategory != "":
+			categorized = append(categorized, doc)
+		case trace.Included:
+			uncategorized = append(uncategorized, doc)
+		case trace.ExcludedBy != "":
+			excluded = append(excluded, doc)
+		default:
+			rejected = append(rejected, doc)
+		}
+	}
+
+	result := Result{
+		Counts: GroupCounts{
+			Categorized:    len(categorized),
+			Uncategorized:  len(uncategorized),
+			Rejected:       len(rejected),
+			Excluded:       len(excluded),
+			FalsePositives: len(falsePositives),
+			FalseNegatives: len(falseNegatives),
+		},
+		Patch: Patch{Categories: make(map[string][]string)},
+	}
+
+	included := concat(categorized, uncategorized, truePositives)
+	known := func(term string) bool { return repoClassifier.CoversTerm(surface[term]) }
+
+	// A term that no missed repository uses would change nothing
+	missed := concat(rejected, falseNegatives)
+	unhelpful := func(term string) bool { return known(term) || len(examples(term, missed)) == 0 }
+	for _, s := range rank(concat(included, falseNegatives), rejected, surface, unhelpful, opts) {
+		s.Examples = examples(textutil.TermKey(s.Term), missed)
+		s.Target = TargetInclude
+		result.Suggestions = append(result.Suggestions, s)
+		result.Patch.Include = append(result.Patch.Include, s.Term)
+	}
+	for _, s := range rank(falsePositives, included, surface, known, opts) {
+		s.Target = TargetExclude
+		result.Suggestions = append(result.Suggestions, s)
+		result.Patch.Exclude = append(result.Patch.Exclude, s.Term)
+	}
+	others := concat(categorized, rejected, excluded)
+	for _, s := range rank(uncategorized, others, surface, known, opts) {
+		s.Target = associatedCategory(textutil.TermKey(s.Term), categorized)
+		result.Suggestions = append(result.Suggestions, s)
+		if s.Target != TargetNewCategory {
+			result.Patch.Categories[s.Target] = append(result.Patch.Categories[s.Target], s.Term)
+		}
+	}
+
+	return result
+}
+
+// concat joins document groups into a new slice
+func concat(groups ...[]document) []document {
+	var docs []document
+	for _, group := range groups {
+		docs = append(docs, group...)
+	}
+	return docs
+}
+
+// rank scores the terms of target documents against reference documents, leaving out
+// terms for which skip returns true
+func rank(target []document, reference []document, surface map[string]string, skip func(string) bool, opts Options) []Suggestion {
+	if len(target) == 0 {
+		return nil
+	}
+
+	targetFreq := documentFrequency(target)
+	referenceFreq := documentFrequency(reference)
+
+	var suggestions []Suggestion
+	for term, support := range targetFreq {
+		if support < opts.MinSupport || skip(term) {
+			continue
+		}
+
+		// Add-one smoothing keeps lift finite for terms the reference group never uses
+		targetRate := float64(support) / float64(len(target))
+		referenceRate := float64(referenceFreq[term]+1) / float64(len(reference)+1)
+		lift := targetRate / referenceRate
+		if lift < opts.MinLift {
+			continue
+		}
+
+		suggestions = append(suggestions, Suggestion{
+			Term:     surface[term],
+			Support:  support,
+			Lift:     lift,
+			Score:    float64(support) * math.Log(1+lift),
+			Examples: examples(term, target),
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Term < suggestions[j].Term
+	})
+	if opts.Limit > 0 && len(suggestions) > opts.Limit {
+		suggestions = suggestions[:opts.Limit]
+	}
+
+	return suggestions
+}
+
+// associatedCategory returns the category whose repositories most often contain term,
+// or TargetNewCategory when none does
+func associatedCategory(term string, categorized []document) string {
+	counts := make(map[string]int)
+	for _, doc := range categorized {
+		if doc.terms[term] {
+			counts[doc.category]++
+		}
+	}
+
+	best, bestCount := TargetNewCategory, 0
+	for category, count := range counts {
+		if count > bestCount || (count == bestCount && category < best) {
+			best, bestCount = category, count
+		}
+	}
+	return best
+}
+
+// documentFrequency counts the documents containing each term
+func documentFrequency(docs []document) map[string]int {
+	frequency := make(map[string]int)
+	for _, doc := range docs {
+		for term := range doc.terms {
+			frequency[term]++
+		}
+	}
+	return frequency
+}
+
+// examples lists up to maxExamples repositories containing term
+func examples(term string, docs []document) []string {
+	var keys []string
+	for _, doc := range docs {
+		if doc.terms[term] {
+			keys = append(keys, doc.key)
+			if len(keys) == maxExamples {
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// extractTerms returns the normalized candidate terms of a repository and records
+// the first surface form seen for each
+func extractTerms(repo models.RepoMetadata, surface map[string]string) map[string]bool {
+	terms := make(map[string]bool)
+	for _, form := range textutil.KeyTerms(repo.Description, repo.Topics) {
+		key := textutil.TermKey(form)
+		terms[key] = true
+		if _, exists := surface[key]; !exists {
+			surface[key] = form
+		}
+	}
+	return terms
+}
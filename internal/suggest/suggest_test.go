@@ -0,0 +1,147 @@
+package suggest
+
+import (
+	"fmt"
+	"testing"
+
+	"ai-repo-insights/internal/classifier"
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/evaluation"
+	"ai-repo-insights/internal/models"
+)
+
+func testRepos() []models.RepoMetadata {
+	repos := []models.RepoMetadata{
+		// Rejected: new jargon the include list does not know yet
+		{Owner: "a", Name: "mcp-server", Description: "MCP server for databases", Topics: []string{"mcp"}},
+		{Owner: "b", Name: "mcp-tools", Description: "Collection of MCP servers", Topics: []string{"mcp"}},
+		{Owner: "c", Name: "vibe", Description: "vibe coding assistant", Topics: []string{"vibe-coding"}},
+		{Owner: "d", Name: "vibe2", Description: "Vibe coding for everyone"},
+		// Categorized, some already using the new jargon
+		{Owner: "e", Name: "planner", Description: "llm agent with browser automation"},
+		{Owner: "f", Name: "crawler", Description: "llm agent for browser automation"},
+		{Owner: "g", Name: "chat", Description: "llm rag chat"},
+		{Owner: "g", Name: "mcp-agent", Description: "llm agent speaking MCP", Topics: []string{"mcp"}},
+		{Owner: "g", Name: "mcp-rag", Description: "llm rag over MCP", Topics: []string{"mcp"}},
+		{Owner: "g", Name: "vibe-agent", Description: "llm agent for vibe coding"},
+		{Owner: "g", Name: "vibe-rag", Description: "llm rag for vibe coding"},
+		// Included but uncategorized, sharing vocabulary with the agent category
+		{Owner: "h", Name: "clicker", Description: "llm browser automation"},
+		{Owner: "i", Name: "surfer", Description: "llm powered browser automation"},
+		// Excluded
+		{Owner: "j", Name: "ai-course", Description: "llm course with lessons for beginners"},
+		{Owner: "k", Name: "ai-book", Description: "llm book with lessons for beginners"},
+	}
+
+	// Rejected: the bulk of a trending list is unrelated to the domain
+	for i := 0; i < 40; i++ {
+		repos = append(repos, models.RepoMetadata{Owner: "web", Name: fmt.Sprintf("framework%d", i), Description: "Fast web framework with routing"})
+	}
+
+	return repos
+}
+
+func testLabels() []evaluation.LabeledRepo {
+	return []evaluation.LabeledRepo{
+		// False positives: included through "llm" but not projects
+		{Metadata: models.RepoMetadata{Owner: "l", Name: "interview", Description: "llm interview questions"}},
+		{Metadata: models.RepoMetadata{Owner: "m", Name: "prep", Description: "interview questions about llm"}},
+		// False negative
+		{Metadata: models.RepoMetadata{Owner: "n", Name: "mcp-hub", Description: "Registry of MCP servers"}, Include: true},
+		// True positive
+		{Metadata: models.RepoMetadata{Owner: "o", Name: "agent-kit", Description: "llm agent toolkit"}, Include: true, Category: "agent"},
+	}
+}
+
+func testKeywords() config.KeywordConfig {
+	return config.KeywordConfig{
+		Include: []string{"llm"},
+		Exclude: []string{"course", "book"},
+		Categories: map[string][]string{
+			"agent": {"agent"},
+			"rag":   {"rag"},
+		},
+	}
+}
+
+func suggestionsFor(result Result, target string) map[string]Suggestion {
+	terms := make(map[string]Suggestion)
+	for _, s := range result.Suggestions {
+		if s.Target == target {
+			terms[s.Term] = s
+		}
+	}
+	return terms
+}
+
+func TestSuggest(t *testing.T) {
+	result := Suggest(classifier.New(testKeywords()), testRepos(), testLabels(), DefaultOptions())
+
+	want := GroupCounts{Categorized: 7, Uncategorized: 2, Rejected: 44, Excluded: 2, FalsePositives: 2, FalseNegatives: 1}
+	if result.Counts != want {
+		t.Errorf("expected counts %+v, got %+v", want, result.Counts)
+	}
+
+	include := suggestionsFor(result, TargetInclude)
+	for _, term := range []string{"mcp", "vibe-coding"} {
+		if _, exists := include[term]; !exists {
+			t.Errorf("expected include suggestion %q, got %+v", term, include)
+		}
+	}
+	// Support counts included repos and labeled positives; examples are the repos it would add
+	if s := include["mcp"]; s.Support != 3 || len(s.Examples) != 3 || s.Examples[2] != "n/mcp-hub" {
+		t.Errorf("expected mcp support 3 with missed repos as examples, got %+v", s)
+	}
+	if _, exists := include["vibe coding"]; exists {
+		t.Error("expected description bigram and topic variants to merge into one term")
+	}
+	for _, term := range []string{"web framework", "routing", "browser automation"} {
+		if _, exists := include[term]; exists {
+			t.Errorf("expected %q not to be suggested for include", term)
+		}
+	}
+
+	exclude := suggestionsFor(result, TargetExclude)
+	if _, exists := exclude["interview questions"]; !exists {
+		t.Errorf("expected exclude suggestion from false positives, got %+v", exclude)
+	}
+	if _, exists := exclude["lessons"]; exists {
+		t.Error("expected already excluded repositories not to be mined")
+	}
+
+	agent := suggestionsFor(result, "agent")
+	if _, exists := agent["browser automation"]; !exists {
+		t.Errorf("expected browser automation to be proposed for agent, got %+v", result.Suggestions)
+	}
+	if len(result.Patch.Categories["agent"]) == 0 || len(result.Patch.Include) == 0 || len(result.Patch.Exclude) == 0 {
+		t.Errorf("expected patch to carry suggestions, got %+v", result.Patch)
+	}
+}
+
+func TestSuggest_WithoutLabels(t *testing.T) {
+	result := Suggest(classifier.New(testKeywords()), testRepos(), nil, DefaultOptions())
+
+	if exclude := suggestionsFor(result, TargetExclude); len(exclude) != 0 {
+		t.Errorf("expected no exclude suggestions without false positives, got %+v", exclude)
+	}
+	if s, exists := suggestionsFor(result, TargetInclude)["mcp"]; !exists || s.Support != 2 {
+		t.Errorf("expected mcp support 2 from included repos, got %+v", s)
+	}
+}
+
+func TestSuggest_Thresholds(t *testing.T) {
+	keywords := config.KeywordConfig{
+		Include:    []string{"llm"},
+		Categories: map[string][]string{"agent": {"agent"}},
+	}
+
+	result := Suggest(classifier.New(keywords), testRepos(), nil, Options{MinSupport: 3, MinLift: 2})
+	if _, exists := suggestionsFor(result, TargetInclude)["mcp"]; exists {
+		t.Error("expected min support to drop terms seen in only two repos")
+	}
+
+	result = Suggest(classifier.New(keywords), testRepos(), nil, Options{MinSupport: 1, MinLift: 1, Limit: 1})
+	if len(suggestionsFor(result, TargetInclude)) != 1 {
+		t.Errorf("expected limit to cap include suggestions, got %+v", suggestionsFor(result, TargetInclude))
+	}
+}
@@ -0,0 +1,48 @@
+package summary
+
+import (
+	"sort"
+
+	"ai-repo-insights/internal/models"
+)
+
+// minBreakoutStars is the fewest projected stars a breakout needs, so small repositories
+// whose growth reaches the forecast cap from a handful of stars do not crowd the list
+const minBreakoutStars = 100
+
+// BuildBreakouts lists up to limit repositories whose forecast daily star gains grow
+// fastest, ranked by fitted growth and then projected stars. Repositories without a
+// forecast, with flat or shrinking projections or projected below minBreakoutStars
+// are left out.
+func (b *Builder) BuildBreakouts(repos []models.ScoredRepo, limit int) []models.BreakoutInfo {
+	var breakouts []models.BreakoutInfo
+	for _, repo := range repos {
+		if repo.Forecast == nil || repo.Forecast.DailyGrowth <= 0 || repo.Forecast.Stars < minBreakoutStars {
+			continue
+		}
+		breakouts = append(breakouts, models.BreakoutInfo{
+			RepoKey:  repo.Key(),
+			RepoName: repo.Repo.Metadata.Name,
+			URL:      repo.Repo.Metadata.URL,
+			Category: categoryName(repo),
+			Heat7:    repo.Heat7,
+			Forecast: repo.Forecast,
+		})
+	}
+
+	sort.SliceStable(breakouts, func(i, j int) bool {
+		fi, fj := breakouts[i].Forecast, breakouts[j].Forecast
+		if fi.DailyGrowth != fj.DailyGrowth {
+			return fi.DailyGrowth > fj.DailyGrowth
+		}
+		if fi.Stars != fj.Stars {
+			return fi.Stars > fj.Stars
+		}
+		return breakouts[i].RepoKey < breakouts[j].RepoKey
+	})
+	if limit > 0 && len(breakouts) > limit {
+		breakouts = breakouts[:limit]
+	}
+
+	return breakouts
+}
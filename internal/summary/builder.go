@@ -1,6 +1,7 @@
 package summary
 
 import (
+	"sort"
 	"time"
 
 	"ai-repo-insights/internal/config"
@@ -26,7 +27,7 @@ func (b *Builder) BuildSummary(
 	runDate string,
 ) models.SummaryJSON {
 	return models.SummaryJSON{
-		Meta:       b.buildMeta(runDate),
+		Meta:       b.buildMeta(runDate, len(topRepos)),
 		Categories: b.aggregateCategories(topRepos),
 		Languages:  b.aggregateLanguages(topRepos),
 		NewRepos:   b.identifyNewRepos(topRepos),
@@ -36,14 +37,15 @@ func (b *Builder) BuildSummary(
 	}
 }
 
-// buildMeta creates metadata for the summary
-func (b *Builder) buildMeta(runDate string) models.MetaInfo {
+// buildMeta creates metadata for the summary from the number of selected repositories
+func (b *Builder) buildMeta(runDate string, selected int) models.MetaInfo {
 	return models.MetaInfo{
 		RunDate:         runDate,
 		WindowDays:      b.settings.WindowDays,
 		ShortWindowDays: b.settings.ShortWindowDays,
 		TopN:            b.settings.TopN,
 		FilterDomain:    b.settings.FilterDomain,
+		Shortfall:       max(b.settings.TopN-selected, 0),
 	}
 }
 
@@ -51,8 +53,11 @@ func (b *Builder) buildMeta(runDate string) models.MetaInfo {
 func (b *Builder) aggregateCategories(repos []models.ScoredRepo) []models.CategoryStats {
 	categoryMap := make(map[string]*categoryAggregator)
 
+	// The Other bucket always exists so uncategorized repositories have a named home
+	categoryMap[config.OtherCategory] = &categoryAggregator{name: config.OtherCategory}
+
 	for _, repo := range repos {
-		category := repo.Repo.PrimaryCategory
+		category := categoryName(repo)
 		if _, exists := categoryMap[category]; !exists {
 			categoryMap[category] = &categoryAggregator{
 				name:     category,
@@ -65,27 +70,45 @@ func (b *Builder) aggregateCategories(repos []models.ScoredRepo) []models.Catego
 		agg.count++
 		agg.heat7Sum += repo.Heat7
 		agg.scoreSum += repo.Score
+		if repo.Lifecycle != "" {
+			if agg.lifecycle == nil {
+				agg.lifecycle = make(map[string]int)
+			}
+			agg.lifecycle[repo.Lifecycle]++
+		}
 	}
 
 	stats := make([]models.CategoryStats, 0, len(categoryMap))
 	for _, agg := range categoryMap {
-		stats = append(stats, models.CategoryStats{
-			Name:     agg.name,
-			Count:    agg.count,
-			AvgHeat7: float64(agg.heat7Sum) / float64(agg.count),
-			AvgScore: float64(agg.scoreSum) / float64(agg.count),
-		})
+		stat := models.CategoryStats{Name: agg.name, Count: agg.count, Lifecycle: agg.lifecycle}
+		if agg.count > 0 {
+			stat.AvgHeat7 = float64(agg.heat7Sum) / float64(agg.count)
+			stat.AvgScore = float64(agg.scoreSum) / float64(agg.count)
+		}
+		stats = append(stats, stat)
 	}
 
+	// Largest categories first with Other last; names break ties so reruns produce identical output
+	sort.Slice(stats, func(i, j int) bool {
+		if (stats[i].Name == config.OtherCategory) != (stats[j].Name == config.OtherCategory) {
+			return stats[j].Name == config.OtherCategory
+		}
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Name < stats[j].Name
+	})
+
 	return stats
 }
 
 // categoryAggregator accumulates category statistics
 type categoryAggregator struct {
-	name     string
-	count    int
-	heat7Sum int
-	scoreSum int
+	name      string
+	count     int
+	heat7Sum  int
+	scoreSum  int
+	lifecycle map[string]int
 }
 
 // aggregateLanguages calculates per-language statistics
@@ -105,6 +128,13 @@ func (b *Builder) aggregateLanguages(repos []models.ScoredRepo) []models.Languag
 		})
 	}
 
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Name < stats[j].Name
+	})
+
 	return stats
 }
 
@@ -140,7 +170,7 @@ func (b *Builder) identifyDarkHorses(repos []models.ScoredRepo) []models.DarkHor
 				Score:    repo.Score,
 				Heat30:   repo.Heat30,
 				Heat7:    repo.Heat7,
-				Category: repo.Repo.PrimaryCategory,
+				Category: categoryName(repo),
 			})
 		}
 	}
@@ -154,14 +184,14 @@ func (b *Builder) identifyRepeaters(repos []models.ScoredRepo, history *models.H
 
 	for _, repo := range repos {
 		key := repo.Key()
-		if histEntry, exists := history.History[key]; exists && histEntry.WeeksInTop >= 2 {
+		if histEntry, exists := history.Entry(&repo.Repo.Metadata); exists && histEntry.WeeksInTop >= 2 {
 			repeaters = append(repeaters, models.RepeaterInfo{
 				RepoKey:      key,
 				RepoName:     repo.Repo.Metadata.Name,
 				URL:          repo.Repo.Metadata.URL,
 				WeeksInTop:   histEntry.WeeksInTop,
 				CurrentHeat7: repo.Heat7,
-				Category:     repo.Repo.PrimaryCategory,
+				Category:     categoryName(repo),
 			})
 		}
 	}
@@ -177,16 +207,36 @@ func (b *Builder) buildTopRepos(repos []models.ScoredRepo) []models.TopRepoInfo
 		topRepos[i] = models.TopRepoInfo{
 			Rank:        i + 1,
 			RepoKey:     repo.Key(),
+			RepoID:      repo.Repo.Metadata.ID,
 			RepoName:    repo.Repo.Metadata.Name,
 			URL:         repo.Repo.Metadata.URL,
-			Category:    repo.Repo.PrimaryCategory,
+			Category:    categoryName(repo),
 			Language:    repo.Repo.Metadata.Language,
 			Heat7:       repo.Heat7,
 			Heat30:      repo.Heat30,
 			Score:       repo.Score,
 			Description: repo.Repo.Metadata.Description,
+			DisplayName: repo.Repo.DisplayName,
+			CuratorNote: repo.Repo.CuratorNote,
+			Pinned:      repo.Repo.Pinned,
+		}
+		topRepos[i].Forecast = repo.Forecast
+		topRepos[i].Lifecycle = repo.Lifecycle
+		for _, flag := range repo.Repo.Anomalies {
+			topRepos[i].Anomalies = append(topRepos[i].Anomalies, flag.Check)
+		}
+		if repo.Confidence != models.ConfidenceHigh {
+			topRepos[i].Confidence = repo.Confidence
 		}
 	}
 
 	return topRepos
 }
+
+// categoryName returns the primary category of a repository, or the Other bucket if it has none
+func categoryName(repo models.ScoredRepo) string {
+	if repo.Repo.PrimaryCategory == "" {
+		return config.OtherCategory
+	}
+	return repo.Repo.PrimaryCategory
+}
@@ -38,9 +38,9 @@ func TestBuildSummary(t *testing.T) {
 				},
 				PrimaryCategory: "llm",
 			},
-			Heat90:       500,
-			Heat30:       200,
-			Acceleration: 50,
+			Heat7:  500,
+			Heat30: 200,
+			Score:  50,
 		},
 		{
 			Repo: models.ClassifiedRepo{
@@ -54,9 +54,9 @@ func TestBuildSummary(t *testing.T) {
 				},
 				PrimaryCategory: "agent",
 			},
-			Heat90:       300,
-			Heat30:       150,
-			Acceleration: 120,
+			Heat7:  300,
+			Heat30: 150,
+			Score:  120,
 		},
 	}
 
@@ -81,10 +81,13 @@ func TestBuildSummary(t *testing.T) {
 	if summary.Meta.FilterDomain != "AI" {
 		t.Errorf("Expected filter_domain AI, got %s", summary.Meta.FilterDomain)
 	}
+	if summary.Meta.Shortfall != 8 {
+		t.Errorf("Expected shortfall 8 for 2 of top 10, got %d", summary.Meta.Shortfall)
+	}
 
 	// Verify categories
-	if len(summary.Categories) != 2 {
-		t.Errorf("Expected 2 categories, got %d", len(summary.Categories))
+	if len(summary.Categories) != 3 {
+		t.Errorf("Expected 2 categories plus Other, got %d", len(summary.Categories))
 	}
 
 	// Verify languages
@@ -104,8 +107,8 @@ func TestBuildSummary(t *testing.T) {
 	if len(summary.DarkHorses) != 1 {
 		t.Errorf("Expected 1 dark horse, got %d", len(summary.DarkHorses))
 	}
-	if summary.DarkHorses[0].Acceleration != 120 {
-		t.Errorf("Expected dark horse acceleration 120, got %d", summary.DarkHorses[0].Acceleration)
+	if summary.DarkHorses[0].Score != 120 {
+		t.Errorf("Expected dark horse score 120, got %d", summary.DarkHorses[0].Score)
 	}
 
 	// Verify repeaters
@@ -140,29 +143,35 @@ func TestAggregateCategories(t *testing.T) {
 			Repo: models.ClassifiedRepo{
 				PrimaryCategory: "llm",
 			},
-			Heat90:       500,
-			Acceleration: 50,
+			Heat7: 500,
+			Score: 50,
 		},
 		{
 			Repo: models.ClassifiedRepo{
 				PrimaryCategory: "llm",
 			},
-			Heat90:       300,
-			Acceleration: 30,
+			Heat7: 300,
+			Score: 30,
 		},
 		{
 			Repo: models.ClassifiedRepo{
 				PrimaryCategory: "agent",
 			},
-			Heat90:       200,
-			Acceleration: 20,
+			Heat7: 200,
+			Score: 20,
 		},
 	}
 
 	stats := builder.aggregateCategories(repos)
 
-	if len(stats) != 2 {
-		t.Fatalf("Expected 2 categories, got %d", len(stats))
+	if len(stats) != 3 {
+		t.Fatalf("Expected 2 categories plus Other, got %d", len(stats))
+	}
+	if stats[0].Name != "llm" || stats[1].Name != "agent" {
+		t.Errorf("Expected categories ordered by count, got %s, %s", stats[0].Name, stats[1].Name)
+	}
+	if stats[2].Name != config.OtherCategory || stats[2].Count != 0 {
+		t.Errorf("Expected an empty Other bucket last, got %+v", stats[2])
 	}
 
 	// Find llm category
@@ -182,14 +191,38 @@ func TestAggregateCategories(t *testing.T) {
 		t.Errorf("Expected llm count 2, got %d", llmStats.Count)
 	}
 
-	expectedAvgHeat90 := (500.0 + 300.0) / 2.0
-	if llmStats.AvgHeat90 != expectedAvgHeat90 {
-		t.Errorf("Expected llm avg_heat_90 %.2f, got %.2f", expectedAvgHeat90, llmStats.AvgHeat90)
+	expectedAvgHeat7 := (500.0 + 300.0) / 2.0
+	if llmStats.AvgHeat7 != expectedAvgHeat7 {
+		t.Errorf("Expected llm avg_heat_7 %.2f, got %.2f", expectedAvgHeat7, llmStats.AvgHeat7)
 	}
 
 	expectedAvgAccel := (50.0 + 30.0) / 2.0
-	if llmStats.AvgAcceleration != expectedAvgAccel {
-		t.Errorf("Expected llm avg_acceleration %.2f, got %.2f", expectedAvgAccel, llmStats.AvgAcceleration)
+	if llmStats.AvgScore != expectedAvgAccel {
+		t.Errorf("Expected llm avg_score %.2f, got %.2f", expectedAvgAccel, llmStats.AvgScore)
+	}
+}
+
+func TestAggregateCategories_Uncategorized(t *testing.T) {
+	builder := NewBuilder(config.Settings{TopN: 10})
+
+	repos := []models.ScoredRepo{
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "a", Name: "x"}}, Heat7: 10, Score: 4},
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "a", Name: "y"}}, Heat7: 30, Score: 8},
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "a", Name: "z"}, PrimaryCategory: "llm"}, Heat7: 5, Score: 1},
+	}
+
+	stats := builder.aggregateCategories(repos)
+	if len(stats) != 2 || stats[0].Name != "llm" || stats[1].Name != config.OtherCategory {
+		t.Fatalf("Expected llm then Other even though Other is larger, got %+v", stats)
+	}
+	if stats[1].Count != 2 || stats[1].AvgHeat7 != 20 {
+		t.Errorf("Expected uncategorized repos in Other, got %+v", stats[1])
+	}
+
+	for _, repo := range builder.buildTopRepos(repos) {
+		if repo.Category == "" {
+			t.Errorf("Expected %s to be listed under a named category", repo.RepoKey)
+		}
 	}
 }
 
@@ -321,9 +354,9 @@ func TestIdentifyDarkHorses(t *testing.T) {
 				},
 				PrimaryCategory: "llm",
 			},
-			Heat90:       500,
-			Heat30:       200,
-			Acceleration: 50,
+			Heat7:  500,
+			Heat30: 200,
+			Score:  50,
 		},
 		{
 			Repo: models.ClassifiedRepo{
@@ -334,9 +367,9 @@ func TestIdentifyDarkHorses(t *testing.T) {
 				},
 				PrimaryCategory: "agent",
 			},
-			Heat90:       300,
-			Heat30:       150,
-			Acceleration: 120,
+			Heat7:  300,
+			Heat30: 150,
+			Score:  120,
 		},
 	}
 
@@ -350,8 +383,8 @@ func TestIdentifyDarkHorses(t *testing.T) {
 	if dh.RepoKey != "owner2/fast-repo" {
 		t.Errorf("Expected owner2/fast-repo, got %s", dh.RepoKey)
 	}
-	if dh.Acceleration != 120 {
-		t.Errorf("Expected acceleration 120, got %d", dh.Acceleration)
+	if dh.Score != 120 {
+		t.Errorf("Expected score 120, got %d", dh.Score)
 	}
 	if dh.Category != "agent" {
 		t.Errorf("Expected category agent, got %s", dh.Category)
@@ -375,7 +408,7 @@ func TestIdentifyRepeaters(t *testing.T) {
 				},
 				PrimaryCategory: "llm",
 			},
-			Heat90: 500,
+			Heat7: 500,
 		},
 		{
 			Repo: models.ClassifiedRepo{
@@ -386,7 +419,7 @@ func TestIdentifyRepeaters(t *testing.T) {
 				},
 				PrimaryCategory: "agent",
 			},
-			Heat90: 300,
+			Heat7: 300,
 		},
 	}
 
@@ -414,8 +447,8 @@ func TestIdentifyRepeaters(t *testing.T) {
 	if rep.WeeksInTop != 3 {
 		t.Errorf("Expected weeks_in_top 3, got %d", rep.WeeksInTop)
 	}
-	if rep.CurrentHeat90 != 500 {
-		t.Errorf("Expected current_heat_90 500, got %d", rep.CurrentHeat90)
+	if rep.CurrentHeat7 != 500 {
+		t.Errorf("Expected current_heat_7 500, got %d", rep.CurrentHeat7)
 	}
 }
 
@@ -438,9 +471,9 @@ func TestBuildTopRepos(t *testing.T) {
 				},
 				PrimaryCategory: "llm",
 			},
-			Heat90:       500,
-			Heat30:       200,
-			Acceleration: 50,
+			Heat7:  500,
+			Heat30: 200,
+			Score:  50,
 		},
 		{
 			Repo: models.ClassifiedRepo{
@@ -453,9 +486,9 @@ func TestBuildTopRepos(t *testing.T) {
 				},
 				PrimaryCategory: "agent",
 			},
-			Heat90:       300,
-			Heat30:       150,
-			Acceleration: 30,
+			Heat7:  300,
+			Heat30: 150,
+			Score:  30,
 		},
 	}
 
@@ -472,8 +505,8 @@ func TestBuildTopRepos(t *testing.T) {
 	if topRepos[0].RepoKey != "owner1/repo1" {
 		t.Errorf("Expected owner1/repo1, got %s", topRepos[0].RepoKey)
 	}
-	if topRepos[0].Heat90 != 500 {
-		t.Errorf("Expected heat_90 500, got %d", topRepos[0].Heat90)
+	if topRepos[0].Heat7 != 500 {
+		t.Errorf("Expected heat_7 500, got %d", topRepos[0].Heat7)
 	}
 
 	// Check second repo
@@ -484,3 +517,107 @@ func TestBuildTopRepos(t *testing.T) {
 		t.Errorf("Expected owner2/repo2, got %s", topRepos[1].RepoKey)
 	}
 }
+
+func TestBuildLeaderboards(t *testing.T) {
+	builder := NewBuilder(config.Settings{
+		Leaderboards: []config.LeaderboardConfig{
+			{Metric: config.LeaderboardStarsToday, Title: "Hottest Today", TopK: 2},
+			{Metric: config.LeaderboardRelativeGrowth, Title: "Biggest Relative Growth", TopK: 3},
+		},
+	})
+
+	repo := func(name string, starsToday, heat7, totalStars int) models.ScoredRepo {
+		return models.ScoredRepo{
+			Repo:       models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: name}},
+			StarsToday: starsToday,
+			Heat7:      heat7,
+			TotalStars: totalStars,
+		}
+	}
+	repos := []models.ScoredRepo{
+		repo("giant", 500, 2000, 100000),
+		repo("riser", 300, 1000, 2000),
+		repo("fresh", 0, 150, 150),
+		repo("steady", 100, 0, 5000),
+	}
+
+	boards := builder.BuildLeaderboards(repos)
+	if len(boards) != 2 {
+		t.Fatalf("expected 2 leaderboards, got %d", len(boards))
+	}
+
+	today := boards[0]
+	if today.Title != "Hottest Today" || len(today.Repos) != 2 {
+		t.Fatalf("expected 2 entries in Hottest Today, got %+v", today)
+	}
+	if today.Repos[0].RepoKey != "o/giant" || today.Repos[1].RepoKey != "o/riser" || today.Repos[1].Rank != 2 {
+		t.Errorf("unexpected stars today ranking: %+v", today.Repos)
+	}
+
+	growth := boards[1]
+	var keys []string
+	for _, entry := range growth.Repos {
+		keys = append(keys, entry.RepoKey)
+	}
+	// riser gained 1000 on 1000 prior stars; fresh's 150 stars are measured against
+	// the minimum base of 100; steady gained nothing and is left off
+	if len(keys) != 3 || keys[0] != "o/fresh" || keys[1] != "o/riser" || keys[2] != "o/giant" {
+		t.Errorf("unexpected relative growth ranking: %v", keys)
+	}
+	if growth.Repos[1].RelativeGrowth != 1 {
+		t.Errorf("expected riser growth 1.0, got %f", growth.Repos[1].RelativeGrowth)
+	}
+}
+
+func TestBuildBreakouts(t *testing.T) {
+	builder := NewBuilder(config.Settings{})
+
+	repo := func(name string, forecast *models.Forecast) models.ScoredRepo {
+		return models.ScoredRepo{
+			Repo:     models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: name}},
+			Forecast: forecast,
+		}
+	}
+	repos := []models.ScoredRepo{
+		repo("none", nil),
+		repo("fading", &models.Forecast{Stars: 900, DailyGrowth: -0.05}),
+		repo("slow", &models.Forecast{Stars: 5000, DailyGrowth: 0.02}),
+		repo("fast", &models.Forecast{Stars: 300, DailyGrowth: 0.15}),
+		repo("faster", &models.Forecast{Stars: 200, DailyGrowth: 0.2}),
+		repo("tiny", &models.Forecast{Stars: 40, DailyGrowth: 0.2}),
+	}
+
+	breakouts := builder.BuildBreakouts(repos, 2)
+	if len(breakouts) != 2 {
+		t.Fatalf("expected 2 breakouts, got %d", len(breakouts))
+	}
+	if breakouts[0].RepoKey != "o/faster" || breakouts[1].RepoKey != "o/fast" {
+		t.Errorf("unexpected breakout order: %s, %s", breakouts[0].RepoKey, breakouts[1].RepoKey)
+	}
+	for _, breakout := range builder.BuildBreakouts(repos, 0) {
+		if breakout.RepoKey == "o/tiny" {
+			t.Error("expected repositories projected below the minimum stars to be left out")
+		}
+	}
+	if len(builder.BuildBreakouts(repos[:2], 5)) != 0 {
+		t.Error("expected no breakouts without growing forecasts")
+	}
+}
+
+func TestAggregateCategories_Lifecycle(t *testing.T) {
+	builder := NewBuilder(config.Settings{TopN: 10})
+	repos := []models.ScoredRepo{
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: "a"}, PrimaryCategory: "agent"}, Lifecycle: "emerging"},
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: "b"}, PrimaryCategory: "agent"}, Lifecycle: "emerging"},
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: "c"}, PrimaryCategory: "agent"}, Lifecycle: "cooling"},
+		{Repo: models.ClassifiedRepo{Metadata: models.RepoMetadata{Owner: "o", Name: "d"}}},
+	}
+
+	stats := builder.aggregateCategories(repos)
+	if stats[0].Name != "agent" || stats[0].Lifecycle["emerging"] != 2 || stats[0].Lifecycle["cooling"] != 1 {
+		t.Errorf("unexpected agent lifecycle counts: %+v", stats[0])
+	}
+	if stats[1].Name != config.OtherCategory || stats[1].Lifecycle != nil {
+		t.Errorf("expected no lifecycle counts for unlabeled repos, got %+v", stats[1])
+	}
+}
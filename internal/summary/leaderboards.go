@@ -0,0 +1,90 @@
+package summary
+
+import (
+	"sort"
+
+	"ai-repo-insights/internal/config"
+	"ai-repo-insights/internal/models"
+)
+
+// minGrowthBase floors the stars a repository held before the week, so a brand-new
+// repository's first stars do not read as near-infinite relative growth
+const minGrowthBase = 100
+
+// BuildLeaderboards ranks every scored repository by each configured leaderboard metric.
+// Repositories with no value for a metric are left off its leaderboard.
+func (b *Builder) BuildLeaderboards(repos []models.ScoredRepo) []models.Leaderboard {
+	var leaderboards []models.Leaderboard
+
+	for _, board := range b.settings.Leaderboards {
+		entries := make([]models.LeaderboardEntry, 0, len(repos))
+		for _, repo := range repos {
+			entry := leaderboardEntry(repo)
+			if leaderboardValue(entry, board.Metric) > 0 {
+				entries = append(entries, entry)
+			}
+		}
+
+		sort.SliceStable(entries, func(i, j int) bool {
+			vi, vj := leaderboardValue(entries[i], board.Metric), leaderboardValue(entries[j], board.Metric)
+			if vi != vj {
+				return vi > vj
+			}
+			if entries[i].Heat30 != entries[j].Heat30 {
+				return entries[i].Heat30 > entries[j].Heat30
+			}
+			return entries[i].RepoKey < entries[j].RepoKey
+		})
+		if len(entries) > board.TopK {
+			entries = entries[:board.TopK]
+		}
+		for i := range entries {
+			entries[i].Rank = i + 1
+		}
+
+		leaderboards = append(leaderboards, models.Leaderboard{
+			Metric: board.Metric,
+			Title:  board.Title,
+			Repos:  entries,
+		})
+	}
+
+	return leaderboards
+}
+
+// leaderboardEntry builds the leaderboard row of a repository
+func leaderboardEntry(repo models.ScoredRepo) models.LeaderboardEntry {
+	base := repo.TotalStars - repo.Heat7
+	if base < minGrowthBase {
+		base = minGrowthBase
+	}
+
+	return models.LeaderboardEntry{
+		RepoKey:        repo.Key(),
+		RepoName:       repo.Repo.Metadata.Name,
+		URL:            repo.Repo.Metadata.URL,
+		Category:       categoryName(repo),
+		StarsToday:     repo.StarsToday,
+		Heat7:          repo.Heat7,
+		Heat30:         repo.Heat30,
+		Score:          repo.Score,
+		TotalStars:     repo.TotalStars,
+		DisplayName:    repo.Repo.DisplayName,
+		RelativeGrowth: float64(repo.Heat7) / float64(base),
+	}
+}
+
+// leaderboardValue returns the value an entry is ranked by for a metric
+func leaderboardValue(entry models.LeaderboardEntry, metric string) float64 {
+	switch metric {
+	case config.LeaderboardStarsToday:
+		return float64(entry.StarsToday)
+	case config.LeaderboardHeat7:
+		return float64(entry.Heat7)
+	case config.LeaderboardScore:
+		return float64(entry.Score)
+	case config.LeaderboardRelativeGrowth:
+		return entry.RelativeGrowth
+	}
+	return 0
+}
@@ -0,0 +1,105 @@
+package textutil
+
+import "strings"
+
+// Words tokenizes text and additionally splits tokens on hyphens and underscores,
+// so "machine-learning", "machine_learning" and "machine learning" yield the same words
+func Words(text string) []string {
+	var words []string
+	for _, token := range Tokenize(text) {
+		for _, word := range strings.FieldsFunc(token, isCompoundSeparator) {
+			if word = strings.Trim(word, edgeTrim); word != "" {
+				words = append(words, word)
+			}
+		}
+	}
+	return words
+}
+
+// isCompoundSeparator reports whether r joins the parts of a compound term
+func isCompoundSeparator(r rune) bool {
+	return r == '-' || r == '_'
+}
+
+// Stem reduces an English word to a light stem by removing plural, -ing and -ed
+// endings and a final silent e ("embeddings" -> "embed", "llms" -> "llm",
+// "libraries" -> "library", "shared" and "share" -> "shar"). A suffix is only removed
+// when at least four letters including a vowel remain, so "string", "spring" and
+// "during" are kept whole. Short words and CJK text are returned unchanged.
+func Stem(word string) string {
+	if len(word) <= 3 || ContainsCJK(word) {
+		return word
+	}
+
+	word = Singular(word)
+
+	switch {
+	case strings.HasSuffix(word, "ing") && isStem(word[:len(word)-3]):
+		return undouble(word[:len(word)-3])
+	case strings.HasSuffix(word, "ed") && !strings.HasSuffix(word, "eed") && isStem(word[:len(word)-2]):
+		return undouble(word[:len(word)-2])
+	case strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "ee") && isStem(word[:len(word)-1]):
+		// Drop a silent e so "share" meets "shared" and "sharing"
+		return word[:len(word)-1]
+	}
+
+	return word
+}
+
+// invariantPlurals end in s but are not plurals of a shorter word
+var invariantPlurals = map[string]bool{"series": true, "species": true}
+
+// Singular removes a plural ending ("agents" -> "agent", "libraries" -> "library",
+// "indexes" -> "index"). Like Stem it keeps at least four letters including a vowel,
+// so "news", "bias" and "does" are kept whole; abbreviations without vowels are the
+// exception ("llms" -> "llm"). Short words and CJK text are returned unchanged.
+func Singular(word string) string {
+	if len(word) <= 3 || ContainsCJK(word) || invariantPlurals[word] {
+		return word
+	}
+
+	var singular string
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		singular = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"):
+		singular = word[:len(word)-2]
+	case strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"),
+		strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zes"):
+		singular = word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		singular = word[:len(word)-1]
+	default:
+		return word
+	}
+
+	if isStem(singular) || isAbbreviation(singular) {
+		return singular
+	}
+	return word
+}
+
+// isAbbreviation reports whether word has no vowels, like "llm" or "cnn"
+func isAbbreviation(word string) bool {
+	return !strings.ContainsAny(word, "aeiouy")
+}
+
+// isStem reports whether what remains after removing a suffix can stand as a stem:
+// at least four letters, including a vowel
+func isStem(stem string) bool {
+	return len(stem) >= 4 && strings.ContainsAny(stem, "aeiouy")
+}
+
+// undouble removes a doubled final consonant left by suffix removal ("embedd" -> "embed")
+func undouble(word string) string {
+	n := len(word)
+	if n < 4 || word[n-1] != word[n-2] {
+		return word
+	}
+	switch word[n-1] {
+	case 'a', 'e', 'i', 'o', 'u', 'l', 's', 'z':
+		return word
+	}
+	return word[:n-1]
+}
@@ -0,0 +1,79 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// KeyTerms returns the candidate key terms of a repository, lowercased: each topic,
+// each description word and each pair of adjacent description words. Stopwords,
+// numbers and single characters are skipped, and pairs never span a skipped word.
+// Variants of one term ("vibe-coding", "vibe coding") share a TermKey.
+func KeyTerms(description string, topics []string) []string {
+	var terms []string
+
+	for _, topic := range topics {
+		if topic = strings.ToLower(strings.TrimSpace(topic)); isKeyTerm(topic) && TermKey(topic) != "" {
+			terms = append(terms, topic)
+		}
+	}
+
+	var previous string
+	for _, token := range Tokenize(strings.ToLower(description)) {
+		if !isKeyTerm(token) || TermKey(token) == "" {
+			previous = ""
+			continue
+		}
+		terms = append(terms, token)
+		if previous != "" {
+			terms = append(terms, previous+" "+token)
+		}
+		previous = token
+	}
+
+	return terms
+}
+
+// TermKey maps hyphen, underscore and space variants and plural or -ing/-ed
+// forms of a term to one key
+func TermKey(term string) string {
+	words := Words(strings.ToLower(term))
+	for i, word := range words {
+		words[i] = Stem(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// isKeyTerm filters out stopwords, numbers and single characters
+func isKeyTerm(token string) bool {
+	if stopwords[token] {
+		return false
+	}
+	if ContainsCJK(token) {
+		return true
+	}
+	if len([]rune(token)) < 2 {
+		return false
+	}
+	for _, r := range token {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// stopwords are common English and GitHub words that never make useful key terms
+var stopwords = map[string]bool{
+	"a": true, "about": true, "all": true, "an": true, "and": true, "any": true, "are": true,
+	"as": true, "at": true, "be": true, "based": true, "best": true, "build": true, "by": true,
+	"can": true, "easy": true, "for": true, "from": true, "fast": true, "github": true,
+	"has": true, "have": true, "how": true, "in": true, "into": true, "is": true, "it": true,
+	"its": true, "just": true, "like": true, "make": true, "more": true, "most": true,
+	"new": true, "no": true, "not": true, "of": true, "on": true, "one": true, "open": true,
+	"or": true, "our": true, "out": true, "over": true, "powerful": true, "project": true,
+	"repo": true, "repository": true, "simple": true, "so": true, "source": true, "that": true,
+	"the": true, "their": true, "this": true, "to": true, "tool": true, "tools": true,
+	"up": true, "use": true, "using": true, "via": true, "we": true, "what": true,
+	"when": true, "which": true, "with": true, "without": true, "you": true, "your": true,
+}
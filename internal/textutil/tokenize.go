@@ -0,0 +1,72 @@
+// Package textutil provides Unicode-aware text helpers shared by keyword matching
+// and text analysis
+package textutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// wordJoiners are punctuation characters kept inside tokens, so that names such as
+// "multi-agent", "node.js", "c++" and "c#" survive tokenization
+const wordJoiners = "-_.+#/'"
+
+// edgeTrim lists joiners stripped from token edges ("agent." -> "agent")
+const edgeTrim = "-_./'"
+
+// IsCJK reports whether r is a Han, Hiragana, Katakana or Hangul character,
+// including the katakana prolonged sound mark, which Unicode assigns to no script
+func IsCJK(r rune) bool {
+	if r == 'ー' || r == 'ｰ' {
+		return true
+	}
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// ContainsCJK reports whether s contains any CJK character
+func ContainsCJK(s string) bool {
+	for _, r := range s {
+		if IsCJK(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tokenize splits text into lowercase tokens. Whitespace and Unicode punctuation
+// (including full-width forms such as "，" and "（") separate tokens, and runs of CJK
+// characters are kept apart from adjacent Latin text, so "基于LLM的智能体框架" yields
+// "基于", "llm" and "的智能体框架". CJK runs are not segmented further; callers match
+// CJK keywords as substrings.
+func Tokenize(text string) []string {
+	var tokens []string
+	var current []rune
+	currentCJK := false
+
+	flush := func() {
+		token := strings.Trim(string(current), edgeTrim)
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+		current = current[:0]
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case (unicode.IsPunct(r) || unicode.IsSymbol(r)) && !strings.ContainsRune(wordJoiners, r):
+			flush()
+		default:
+			cjk := IsCJK(r)
+			if len(current) > 0 && cjk != currentCJK {
+				flush()
+			}
+			current = append(current, r)
+			currentCJK = cjk
+		}
+	}
+	flush()
+
+	return tokens
+}
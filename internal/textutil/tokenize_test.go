@@ -0,0 +1,153 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"An LLM agent framework.", []string{"an", "llm", "agent", "framework"}},
+		{"multi-agent (node.js, c++ and c#)", []string{"multi-agent", "node.js", "c++", "and", "c#"}},
+		{"基于大模型的智能体框架", []string{"基于大模型的智能体框架"}},
+		{"基于LLM的智能体框架", []string{"基于", "llm", "的智能体框架"}},
+		{"智能体，RAG（检索增强）！", []string{"智能体", "rag", "检索增强"}},
+		{"「エージェント」フレームワーク", []string{"エージェント", "フレームワーク"}},
+		{"✨ rocket 🚀 launch", []string{"rocket", "launch"}},
+		{"'quoted' -dash-", []string{"quoted", "dash"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := Tokenize(tt.input)
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Tokenize(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestContainsCJK(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"agent", false},
+		{"智能体", true},
+		{"rag 检索", true},
+		{"에이전트", true},
+		{"café", false},
+	}
+
+	for _, tt := range tests {
+		if got := ContainsCJK(tt.input); got != tt.expected {
+			t.Errorf("ContainsCJK(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	for _, input := range []string{"machine-learning", "machine_learning", "Machine Learning"} {
+		if got := strings.Join(Words(input), " "); got != "machine learning" {
+			t.Errorf("Words(%q) = %q, want %q", input, got, "machine learning")
+		}
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"embeddings", "embed"},
+		{"embedding", "embed"},
+		{"embedded", "embed"},
+		{"embed", "embed"},
+		{"llms", "llm"},
+		{"agents", "agent"},
+		{"libraries", "library"},
+		{"indexes", "index"},
+		{"running", "run"},
+		{"installed", "install"},
+		{"class", "class"},
+		{"status", "status"},
+		{"analysis", "analysis"},
+		{"rag", "rag"},
+		{"need", "need"},
+		{"needed", "need"},
+		{"shared", "shar"},
+		{"share", "shar"},
+		{"sharing", "shar"},
+		{"string", "string"},
+		{"strings", "string"},
+		{"spring", "spring"},
+		{"during", "during"},
+		{"indeed", "indeed"},
+		{"coding", "coding"},
+		{"news", "news"},
+		{"bias", "bias"},
+		{"does", "does"},
+		{"species", "species"},
+		{"series", "series"},
+		{"boxes", "boxes"},
+		{"cnns", "cnn"},
+		{"智能体", "智能体"},
+	}
+
+	for _, tt := range tests {
+		if got := Stem(tt.input); got != tt.expected {
+			t.Errorf("Stem(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"agents", "agent"},
+		{"libraries", "library"},
+		{"llms", "llm"},
+		{"learning", "learning"},
+		{"learns", "learn"},
+		{"news", "news"},
+		{"species", "species"},
+	}
+
+	for _, tt := range tests {
+		if got := Singular(tt.input); got != tt.expected {
+			t.Errorf("Singular(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestKeyTerms(t *testing.T) {
+	terms := KeyTerms("The MCP server for 3 databases", []string{"Vibe-Coding", "a"})
+	expected := []string{"vibe-coding", "mcp", "server", "mcp server", "databases"}
+
+	if strings.Join(terms, "|") != strings.Join(expected, "|") {
+		t.Errorf("KeyTerms = %q, want %q", terms, expected)
+	}
+}
+
+func TestTermKey(t *testing.T) {
+	tests := []struct {
+		a string
+		b string
+	}{
+		{"vibe-coding", "Vibe coding"},
+		{"mcp servers", "mcp server"},
+		{"model_context_protocol", "model context protocol"},
+	}
+
+	for _, tt := range tests {
+		if TermKey(tt.a) != TermKey(tt.b) {
+			t.Errorf("expected %q and %q to share a key, got %q and %q", tt.a, tt.b, TermKey(tt.a), TermKey(tt.b))
+		}
+	}
+}
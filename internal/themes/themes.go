@@ -0,0 +1,294 @@
+package themes
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"ai-repo-insights/internal/models"
+	"ai-repo-insights/internal/textutil"
+)
+
+// Options tunes clustering and which clusters are reported
+type Options struct {
+	// MinClusterSize is the smallest cluster reported as a theme
+	MinClusterSize int
+	// SimilarityThreshold stops merging clusters whose average cosine similarity is lower
+	SimilarityThreshold float64
+	// MaxThemes caps the number of themes returned; 0 means no limit
+	MaxThemes int
+	// LabelTerms is the number of top terms used to label a cluster
+	LabelTerms int
+}
+
+// vector is a sparse TF-IDF vector keyed by term key
+type vector map[string]float64
+
+// document is one repository prepared for clustering
+type document struct {
+	repo    models.ScoredRepo
+	terms   map[string]int
+	weights vector
+}
+
+// cluster is a group of document indexes
+type cluster struct {
+	members []int
+}
+
+// Discover clusters repositories by TF-IDF similarity of their descriptions and topics
+// and returns the clusters whose label terms no configured category covers.
+// covered reports whether a category keyword already matches a term.
+//
+// Clustering is average-linkage agglomerative: every repository starts alone and the
+// two most similar clusters merge until no pair reaches SimilarityThreshold.
+// Repositories are processed in key order so results are stable across runs.
+func Discover(repos []models.ScoredRepo, covered func(term string) bool, opts Options) []models.EmergingTheme {
+	docs, surface := buildDocuments(repos)
+	if len(docs) < opts.MinClusterSize || len(docs) < 2 {
+		return nil
+	}
+
+	var themes []models.EmergingTheme
+	for _, c := range agglomerate(docs, opts.SimilarityThreshold) {
+		if len(c.members) < opts.MinClusterSize {
+			continue
+		}
+
+		terms := labelTerms(docs, c, surface, opts.LabelTerms)
+		if len(terms) == 0 || anyCovered(terms, covered) {
+			continue
+		}
+		themes = append(themes, describe(docs, c, terms))
+	}
+
+	sort.SliceStable(themes, func(i, j int) bool {
+		if len(themes[i].Repos) != len(themes[j].Repos) {
+			return len(themes[i].Repos) > len(themes[j].Repos)
+		}
+		if themes[i].AvgHeat7 != themes[j].AvgHeat7 {
+			return themes[i].AvgHeat7 > themes[j].AvgHeat7
+		}
+		return themes[i].Label < themes[j].Label
+	})
+	if opts.MaxThemes > 0 && len(themes) > opts.MaxThemes {
+		themes = themes[:opts.MaxThemes]
+	}
+
+	return themes
+}
+
+// buildDocuments extracts term counts, computes L2-normalized TF-IDF weights and
+// returns the first surface form of each term key
+func buildDocuments(repos []models.ScoredRepo) ([]document, map[string]string) {
+	sorted := append([]models.ScoredRepo{}, repos...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Key() < sorted[j].Key() })
+
+	surface := make(map[string]string)
+	documentFrequency := make(map[string]int)
+	docs := make([]document, 0, len(sorted))
+
+	for _, repo := range sorted {
+		terms := make(map[string]int)
+		for _, form := range textutil.KeyTerms(repo.Repo.Metadata.Description, repo.Repo.Metadata.Topics) {
+			key := textutil.TermKey(form)
+			if terms[key] == 0 {
+				documentFrequency[key]++
+			}
+			terms[key]++
+			if _, exists := surface[key]; !exists {
+				surface[key] = form
+			}
+		}
+		if len(terms) > 0 {
+			docs = append(docs, document{repo: repo, terms: terms})
+		}
+	}
+
+	n := float64(len(docs))
+	for i := range docs {
+		weights := make(vector, len(docs[i].terms))
+		var norm float64
+		for key, count := range docs[i].terms {
+			// Smoothed IDF, as in common TF-IDF implementations
+			weight := float64(count) * (math.Log((1+n)/(1+float64(documentFrequency[key]))) + 1)
+			weights[key] = weight
+			norm += weight * weight
+		}
+		norm = math.Sqrt(norm)
+		for key := range weights {
+			weights[key] /= norm
+		}
+		docs[i].weights = weights
+	}
+
+	return docs, surface
+}
+
+// agglomerate merges clusters by average linkage until the best pair falls below threshold
+func agglomerate(docs []document, threshold float64) []cluster {
+	n := len(docs)
+	clusters := make([]cluster, n)
+	similarity := make([][]float64, n)
+	for i := range docs {
+		clusters[i] = cluster{members: []int{i}}
+		similarity[i] = make([]float64, n)
+		for j := 0; j < i; j++ {
+			similarity[i][j] = dot(docs[i].weights, docs[j].weights)
+			similarity[j][i] = similarity[i][j]
+		}
+	}
+
+	active := make([]bool, n)
+	for i := range active {
+		active[i] = true
+	}
+
+	for {
+		bestI, bestJ, best := -1, -1, threshold
+		for i := 0; i < n; i++ {
+			if !active[i] {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if active[j] && similarity[i][j] >= best && (bestI < 0 || similarity[i][j] > best) {
+					bestI, bestJ, best = i, j, similarity[i][j]
+				}
+			}
+		}
+		if bestI < 0 {
+			break
+		}
+
+		// Lance-Williams update for average linkage
+		sizeI := float64(len(clusters[bestI].members))
+		sizeJ := float64(len(clusters[bestJ].members))
+		for k := 0; k < n; k++ {
+			if !active[k] || k == bestI || k == bestJ {
+				continue
+			}
+			merged := (sizeI*similarity[bestI][k] + sizeJ*similarity[bestJ][k]) / (sizeI + sizeJ)
+			similarity[bestI][k] = merged
+			similarity[k][bestI] = merged
+		}
+		clusters[bestI].members = append(clusters[bestI].members, clusters[bestJ].members...)
+		active[bestJ] = false
+	}
+
+	var result []cluster
+	for i, c := range clusters {
+		if active[i] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// labelTerms returns the highest-weighted centroid terms shared by at least two members
+func labelTerms(docs []document, c cluster, surface map[string]string, limit int) []string {
+	centroid := make(vector)
+	shared := make(map[string]int)
+	for _, member := range c.members {
+		for key, weight := range docs[member].weights {
+			centroid[key] += weight
+			shared[key]++
+		}
+	}
+
+	var keys []string
+	for key := range centroid {
+		if shared[key] >= 2 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if centroid[keys[i]] != centroid[keys[j]] {
+			return centroid[keys[i]] > centroid[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	var terms []string
+	for _, key := range keys {
+		if len(terms) == limit {
+			break
+		}
+		if overlapsLabel(key, terms) {
+			continue
+		}
+		terms = append(terms, surface[key])
+	}
+	return terms
+}
+
+// overlapsLabel reports whether a term key repeats a word already in the label,
+// so "mcp" and "mcp server" do not both appear
+func overlapsLabel(key string, terms []string) bool {
+	for _, term := range terms {
+		for _, word := range strings.Fields(textutil.TermKey(term)) {
+			for _, keyWord := range strings.Fields(key) {
+				if word == keyWord {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// describe builds the theme summary of a cluster
+func describe(docs []document, c cluster, terms []string) models.EmergingTheme {
+	theme := models.EmergingTheme{
+		Label: strings.Join(terms, " / "),
+		Terms: terms,
+	}
+
+	categoryCounts := make(map[string]int)
+	var heat7Sum int
+	for _, member := range c.members {
+		repo := docs[member].repo
+		theme.Repos = append(theme.Repos, repo.Key())
+		heat7Sum += repo.Heat7
+		categoryCounts[repo.Repo.PrimaryCategory]++
+	}
+	sort.Strings(theme.Repos)
+	theme.AvgHeat7 = float64(heat7Sum) / float64(len(c.members))
+
+	bestCount := 0
+	for category, count := range categoryCounts {
+		if category == "" {
+			continue
+		}
+		if count > bestCount || (count == bestCount && category < theme.DominantCategory) {
+			theme.DominantCategory, bestCount = category, count
+		}
+	}
+	theme.CategoryShare = float64(bestCount) / float64(len(c.members))
+
+	return theme
+}
+
+// anyCovered reports whether covered accepts any of the terms
+func anyCovered(terms []string, covered func(string) bool) bool {
+	if covered == nil {
+		return false
+	}
+	for _, term := range terms {
+		if covered(term) {
+			return true
+		}
+	}
+	return false
+}
+
+// dot returns the dot product of two sparse vectors
+func dot(a vector, b vector) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var sum float64
+	for key, weight := range a {
+		sum += weight * b[key]
+	}
+	return sum
+}
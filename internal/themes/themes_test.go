@@ -0,0 +1,100 @@
+package themes
+
+import (
+	"strings"
+	"testing"
+
+	"ai-repo-insights/internal/models"
+)
+
+func scored(owner string, name string, category string, heat7 int, description string, topics ...string) models.ScoredRepo {
+	return models.ScoredRepo{
+		Repo: models.ClassifiedRepo{
+			Metadata:        models.RepoMetadata{Owner: owner, Name: name, Description: description, Topics: topics},
+			PrimaryCategory: category,
+		},
+		Heat7: heat7,
+	}
+}
+
+func testRepos() []models.ScoredRepo {
+	return []models.ScoredRepo{
+		scored("a", "mcp-github", "agent", 300, "MCP server for GitHub", "mcp"),
+		scored("b", "mcp-postgres", "", 100, "MCP server exposing Postgres", "mcp"),
+		scored("c", "mcp-browser", "agent", 200, "Browser MCP server", "mcp", "model-context-protocol"),
+		scored("d", "planner", "agent", 50, "Autonomous agent planner for long tasks", "agent"),
+		scored("e", "crew", "agent", 40, "Multi agent planner framework", "agent"),
+		scored("f", "swarm", "agent", 30, "Lightweight agent planner", "agent"),
+		scored("g", "paint", "vision", 20, "Diffusion image editor"),
+	}
+}
+
+func defaultOptions() Options {
+	return Options{MinClusterSize: 3, SimilarityThreshold: 0.2, MaxThemes: 5, LabelTerms: 3}
+}
+
+func TestDiscover(t *testing.T) {
+	covered := func(term string) bool { return strings.Contains(term, "agent") }
+
+	themes := Discover(testRepos(), covered, defaultOptions())
+	if len(themes) != 1 {
+		t.Fatalf("expected only the MCP cluster to be reported, got %+v", themes)
+	}
+
+	theme := themes[0]
+	if theme.Terms[0] != "mcp" {
+		t.Errorf("expected mcp to lead the label, got %v", theme.Terms)
+	}
+	if strings.Join(theme.Repos, ",") != "a/mcp-github,b/mcp-postgres,c/mcp-browser" {
+		t.Errorf("unexpected members %v", theme.Repos)
+	}
+	if theme.AvgHeat7 != 200 {
+		t.Errorf("expected average heat 200, got %f", theme.AvgHeat7)
+	}
+	if theme.DominantCategory != "agent" || theme.CategoryShare < 0.66 || theme.CategoryShare > 0.67 {
+		t.Errorf("expected agent at 2/3 share, got %s %f", theme.DominantCategory, theme.CategoryShare)
+	}
+}
+
+func TestDiscover_Options(t *testing.T) {
+	if themes := Discover(testRepos(), nil, defaultOptions()); len(themes) != 2 {
+		t.Errorf("expected both clusters without a coverage check, got %+v", themes)
+	}
+
+	opts := defaultOptions()
+	opts.MinClusterSize = 4
+	if themes := Discover(testRepos(), nil, opts); len(themes) != 0 {
+		t.Errorf("expected no cluster of four, got %+v", themes)
+	}
+
+	opts = defaultOptions()
+	opts.MaxThemes = 1
+	themes := Discover(testRepos(), nil, opts)
+	if len(themes) != 1 || themes[0].Terms[0] != "mcp" {
+		t.Errorf("expected the hotter cluster first when capped, got %+v", themes)
+	}
+
+	if themes := Discover(nil, nil, defaultOptions()); themes != nil {
+		t.Errorf("expected no themes for no repos, got %+v", themes)
+	}
+}
+
+func TestDiscover_Deterministic(t *testing.T) {
+	repos := testRepos()
+	first := Discover(repos, nil, defaultOptions())
+
+	reversed := make([]models.ScoredRepo, len(repos))
+	for i, repo := range repos {
+		reversed[len(repos)-1-i] = repo
+	}
+	second := Discover(reversed, nil, defaultOptions())
+
+	if len(first) != len(second) {
+		t.Fatalf("expected the same themes regardless of input order")
+	}
+	for i := range first {
+		if first[i].Label != second[i].Label {
+			t.Errorf("theme %d label %q != %q", i, first[i].Label, second[i].Label)
+		}
+	}
+}